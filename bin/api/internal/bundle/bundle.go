@@ -0,0 +1,365 @@
+// Package bundle merges individual per-method OpenAPI spec files into a
+// single document, the way main.go's historical bundleAPISpecs tried to
+// (by walking typed Go maps) but without the data loss that approach had:
+// duplicate schemas are deduplicated by content hash instead of silently
+// overwritten, duplicate paths are merged operation-by-operation instead
+// of dropped, and x-errors entries are deduplicated by code+mnemonic.
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Options configures a single Bundle call.
+type Options struct {
+	// Title and Description populate the bundled document's info block.
+	Title       string
+	Description string
+	// Version is the bundled document's info.version. Defaults to "1.0.0".
+	Version string
+}
+
+// Bundle merges inputFiles (each an OpenAPI 3.0 YAML document produced by
+// the generator) into one document, in the given order, and returns its
+// YAML encoding. inputFiles order determines both the order specs are
+// merged in and, since each spec's own path order is preserved, the final
+// document's path order.
+func Bundle(inputFiles []string, opts Options) ([]byte, error) {
+	b := newBundler(opts)
+
+	for _, file := range inputFiles {
+		if err := b.merge(file); err != nil {
+			return nil, fmt.Errorf("merge %s: %w", file, err)
+		}
+	}
+
+	return b.marshal()
+}
+
+// bundler accumulates merged paths, deduplicated schemas, and deduplicated
+// x-errors entries across every input file.
+type bundler struct {
+	opts Options
+
+	pathOrder []string
+	paths     map[string]*yaml.Node
+
+	schemasNode *yaml.Node          // components/schemas mapping node
+	schemaOrder []string            // names in the order they were added
+	schemaByKey map[string]string   // content hash -> assigned name
+	usedNames   map[string]bool     // assigned names already taken
+	xErrors     []*yaml.Node        // deduplicated x-errors entries
+	xErrorKeys  map[string]struct{} // code+"\x00"+mnemonic already seen
+}
+
+func newBundler(opts Options) *bundler {
+	if opts.Version == "" {
+		opts.Version = "1.0.0"
+	}
+	return &bundler{
+		opts:        opts,
+		paths:       make(map[string]*yaml.Node),
+		schemasNode: &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+		schemaByKey: make(map[string]string),
+		usedNames:   make(map[string]bool),
+		xErrorKeys:  make(map[string]struct{}),
+	}
+}
+
+// merge loads file, resolves any cross-file $refs it contains, hoists its
+// duplicated inline schemas into shared components, and folds its paths
+// and x-errors into the bundle.
+func (b *bundler) merge(file string) error {
+	root, err := loadYAMLFile(file)
+	if err != nil {
+		return err
+	}
+
+	resolver := &refResolver{baseDir: filepath.Dir(file), cache: make(map[string]*yaml.Node), visiting: make(map[string]bool)}
+	if err := resolver.resolve(root, make(map[*yaml.Node]bool)); err != nil {
+		return fmt.Errorf("resolve refs: %w", err)
+	}
+
+	doc := mappingNode(root)
+	if doc == nil {
+		return fmt.Errorf("not a mapping document")
+	}
+
+	// mergeComponents must run before mergePaths: when a schema collides
+	// under a name already used by a different schema, it's renamed, and
+	// this file's own paths (and any cross-schema $refs inside its own
+	// components) still point at the original name until rewriteSchemaRefs
+	// rewrites doc in place below.
+	rename := b.mergeComponents(mappingValue(doc, "components"))
+	if len(rename) > 0 {
+		rewriteSchemaRefs(doc, rename)
+	}
+
+	b.mergePaths(mappingValue(doc, "paths"))
+	b.mergeXErrors(mappingValue(doc, "x-errors"))
+
+	return nil
+}
+
+// mergePaths folds pathsNode's entries into the bundle, preserving the
+// order they appear in pathsNode. A path that already exists has its
+// operations merged in (get/post/...) rather than being dropped; an
+// operation that collides too is kept from the first file that defined
+// it and the conflict is reported via os.Stderr so it isn't silent.
+func (b *bundler) mergePaths(pathsNode *yaml.Node) {
+	if pathsNode == nil || pathsNode.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(pathsNode.Content); i += 2 {
+		path := pathsNode.Content[i].Value
+		item := pathsNode.Content[i+1]
+
+		existing, ok := b.paths[path]
+		if !ok {
+			b.paths[path] = item
+			b.pathOrder = append(b.pathOrder, path)
+			continue
+		}
+
+		mergeOperations(existing, item, path)
+	}
+}
+
+// mergeOperations copies operation keys (get/post/put/...) from src into
+// dst for path, skipping (and warning about) any that dst already has.
+func mergeOperations(dst, src *yaml.Node, path string) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		op := src.Content[i].Value
+		if mappingValue(dst, op) != nil {
+			fmt.Fprintf(os.Stderr, "bundle: %s %s already defined, keeping first definition\n", op, path)
+			continue
+		}
+		dst.Content = append(dst.Content, src.Content[i], src.Content[i+1])
+	}
+}
+
+// mergeComponents hoists every schema under componentsNode's "schemas"
+// mapping into the bundle's shared schema set, deduplicating by content
+// hash: identical shapes collapse onto one name; divergent shapes that
+// happen to share a name get a hash-suffixed name instead. It returns the
+// set of names that were renamed (original -> assigned), so the caller can
+// rewrite this file's own $refs to match.
+func (b *bundler) mergeComponents(componentsNode *yaml.Node) map[string]string {
+	if componentsNode == nil {
+		return nil
+	}
+
+	schemas := mappingValue(componentsNode, "schemas")
+	if schemas == nil || schemas.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var rename map[string]string
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		name := schemas.Content[i].Value
+		node := schemas.Content[i+1]
+		assigned := b.addSchema(name, node)
+		if assigned != name {
+			if rename == nil {
+				rename = make(map[string]string)
+			}
+			rename[name] = assigned
+		}
+	}
+
+	return rename
+}
+
+// rewriteSchemaRefs walks node in place, rewriting any
+// "$ref": "#/components/schemas/<old>" to "#/components/schemas/<new>" per
+// rename. Used right after mergeComponents renames a colliding schema, so
+// this file's own paths and cross-schema $refs still resolve to the copy
+// that was actually kept under the bundle's name.
+func rewriteSchemaRefs(node *yaml.Node, rename map[string]string) {
+	if node == nil {
+		return
+	}
+
+	const prefix = "#/components/schemas/"
+	if node.Kind == yaml.MappingNode {
+		if ref := mappingValue(node, "$ref"); ref != nil && strings.HasPrefix(ref.Value, prefix) {
+			name := strings.TrimPrefix(ref.Value, prefix)
+			if newName, ok := rename[name]; ok {
+				ref.Value = prefix + newName
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		rewriteSchemaRefs(child, rename)
+	}
+}
+
+// addSchema registers node under name (deduplicating by content hash) and
+// returns the name it was ultimately stored under.
+func (b *bundler) addSchema(name string, node *yaml.Node) string {
+	hash := hashNode(node)
+	if existing, ok := b.schemaByKey[hash]; ok {
+		return existing
+	}
+
+	assigned := name
+	if b.usedNames[assigned] {
+		assigned = fmt.Sprintf("%s_%s", name, hash[:8])
+	}
+	b.usedNames[assigned] = true
+	b.schemaByKey[hash] = assigned
+	b.schemaOrder = append(b.schemaOrder, assigned)
+	b.schemasNode.Content = append(b.schemasNode.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: assigned}, node)
+
+	return assigned
+}
+
+// mergeXErrors folds xErrorsNode's "errors" list into the bundle,
+// deduplicating by code+mnemonic rather than blindly appending.
+func (b *bundler) mergeXErrors(xErrorsNode *yaml.Node) {
+	if xErrorsNode == nil {
+		return
+	}
+
+	errorsList := mappingValue(xErrorsNode, "errors")
+	if errorsList == nil || errorsList.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, entry := range errorsList.Content {
+		code := mappingValue(entry, "code")
+		mnemonic := mappingValue(entry, "mnemonic")
+		var key string
+		if code != nil {
+			key += code.Value
+		}
+		key += "\x00"
+		if mnemonic != nil {
+			key += mnemonic.Value
+		}
+
+		if _, seen := b.xErrorKeys[key]; seen {
+			continue
+		}
+		b.xErrorKeys[key] = struct{}{}
+		b.xErrors = append(b.xErrors, entry)
+	}
+}
+
+// marshal renders the accumulated bundle as an OpenAPI 3.0 YAML document.
+func (b *bundler) marshal() ([]byte, error) {
+	pathsNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, path := range b.pathOrder {
+		pathsNode.Content = append(pathsNode.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path}, b.paths[path])
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	appendField(root, "openapi", scalar("3.0.0"))
+	appendField(root, "info", infoNode(b.opts))
+	appendField(root, "paths", pathsNode)
+
+	if len(b.schemaOrder) > 0 {
+		components := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		appendField(components, "schemas", b.schemasNode)
+		appendField(root, "components", components)
+	}
+
+	if len(b.xErrors) > 0 {
+		errorsList := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: b.xErrors}
+		xErrors := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		appendField(xErrors, "errors", errorsList)
+		appendField(root, "x-errors", xErrors)
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+	return yaml.Marshal(doc)
+}
+
+func infoNode(opts Options) *yaml.Node {
+	info := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	appendField(info, "title", scalar(opts.Title))
+	appendField(info, "version", scalar(opts.Version))
+	appendField(info, "description", scalar(opts.Description))
+	return info
+}
+
+func appendField(m *yaml.Node, key string, value *yaml.Node) {
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func scalar(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// hashNode returns a content hash of node's canonical YAML encoding, used
+// to detect structurally identical schemas regardless of which file they
+// came from.
+func hashNode(node *yaml.Node) string {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		// Nodes are always marshalable; fall back to a key unique to this
+		// node (so it is never deduplicated) rather than failing the
+		// whole bundle.
+		return fmt.Sprintf("err-%p", node)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// loadYAMLFile reads file and parses it as a single YAML document.
+func loadYAMLFile(file string) (*yaml.Node, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	return &root, nil
+}
+
+// mappingNode unwraps a DocumentNode down to its root mapping, or returns
+// node itself if it's already a mapping.
+func mappingNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return mappingNode(node.Content[0])
+	}
+	if node.Kind == yaml.MappingNode {
+		return node
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key within mapping m, or nil if
+// m isn't a mapping or doesn't have key.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func writeSpec(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// refTarget returns the $ref string found under
+// paths./<method>.post.requestBody.content.application/json.schema of doc.
+func refTarget(t *testing.T, doc map[string]interface{}, method string) string {
+	t.Helper()
+	paths := doc["paths"].(map[string]interface{})
+	op := paths["/"+method].(map[string]interface{})["post"].(map[string]interface{})
+	body := op["requestBody"].(map[string]interface{})
+	schema := body["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	ref, _ := schema["$ref"].(string)
+	return ref
+}
+
+func unmarshalBundle(t *testing.T, out []byte) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("failed to unmarshal bundled output: %v", err)
+	}
+	return doc
+}
+
+// TestBundle_RenamesCollidingSchemaAndRewritesOwnRefs reproduces two source
+// specs that each define a same-named but differently-shaped JsonRpcError
+// component (the way two methods' generated specs each embed their own
+// error-code enum) and asserts that the second file's own path still
+// resolves to its own (renamed) copy, rather than silently pointing at the
+// first file's component.
+func TestBundle_RenamesCollidingSchemaAndRewritesOwnRefs(t *testing.T) {
+	dir := t.TempDir()
+
+	specOne := `
+openapi: 3.0.0
+info:
+  title: One
+  version: 1.0.0
+paths:
+  /method.one:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/JsonRpcError'
+components:
+  schemas:
+    JsonRpcError:
+      type: object
+      properties:
+        code:
+          type: integer
+          enum: [-1, -2]
+`
+	specTwo := `
+openapi: 3.0.0
+info:
+  title: Two
+  version: 1.0.0
+paths:
+  /method.two:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/JsonRpcError'
+components:
+  schemas:
+    JsonRpcError:
+      type: object
+      properties:
+        code:
+          type: integer
+          enum: [-10, -20]
+`
+	fileOne := writeSpec(t, dir, "one.yaml", specOne)
+	fileTwo := writeSpec(t, dir, "two.yaml", specTwo)
+
+	out, err := Bundle([]string{fileOne, fileTwo}, Options{Title: "Bundled"})
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	doc := unmarshalBundle(t, out)
+
+	refOne := refTarget(t, doc, "method.one")
+	refTwo := refTarget(t, doc, "method.two")
+
+	if refOne == "" || refTwo == "" {
+		t.Fatalf("expected both methods to have a requestBody $ref, got %q and %q", refOne, refTwo)
+	}
+	if refOne == refTwo {
+		t.Fatalf("expected the two methods' divergent JsonRpcError schemas to resolve to different components, both point at %q", refOne)
+	}
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if len(schemas) != 2 {
+		t.Fatalf("expected 2 distinct schemas in components.schemas, got %d: %v", len(schemas), schemas)
+	}
+
+	for _, ref := range []string{refOne, refTwo} {
+		name := ref[len("#/components/schemas/"):]
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("expected schemas to contain %q (referenced by %q)", name, ref)
+		}
+	}
+}
+
+// TestBundle_DeduplicatesIdenticalSchemas confirms the non-colliding case
+// still dedupes: two files defining the exact same schema under the same
+// name should collapse onto a single shared component.
+func TestBundle_DeduplicatesIdenticalSchemas(t *testing.T) {
+	dir := t.TempDir()
+
+	spec := `
+openapi: 3.0.0
+info:
+  title: %s
+  version: 1.0.0
+paths:
+  /method.%s:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Shared'
+components:
+  schemas:
+    Shared:
+      type: object
+      properties:
+        id:
+          type: string
+`
+	fileOne := writeSpec(t, dir, "one.yaml", fmt.Sprintf(spec, "One", "one"))
+	fileTwo := writeSpec(t, dir, "two.yaml", fmt.Sprintf(spec, "Two", "two"))
+
+	out, err := Bundle([]string{fileOne, fileTwo}, Options{Title: "Bundled"})
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	doc := unmarshalBundle(t, out)
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if len(schemas) != 1 {
+		t.Fatalf("expected identical schemas across files to be deduplicated into 1, got %d: %v", len(schemas), schemas)
+	}
+
+	refOne := refTarget(t, doc, "method.one")
+	refTwo := refTarget(t, doc, "method.two")
+	if refOne != refTwo {
+		t.Errorf("expected both methods to share the deduplicated component, got %q and %q", refOne, refTwo)
+	}
+}
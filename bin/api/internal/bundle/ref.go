@@ -0,0 +1,138 @@
+package bundle
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// refResolver inlines cross-file $ref: "file.yaml#/a/b/c" references found
+// while walking a spec, so bundler.merge only ever has to deal with a
+// single self-contained document. File contents are cached so a file
+// referenced from several places is only read and parsed once.
+type refResolver struct {
+	baseDir  string
+	cache    map[string]*yaml.Node // file path -> parsed root mapping
+	visiting map[string]bool       // "file#/pointer" currently being resolved
+}
+
+// resolve walks node in place, replacing any $ref mapping whose target is
+// a file reference (any ref not starting with "#", as opposed to an
+// in-document "#/components/..." ref, which is left untouched for the
+// OpenAPI consumer to resolve itself). visited tracks node pointers
+// already on the current recursion stack so a self-referential schema
+// (e.g. a tree-shaped call record referencing itself) can't recurse
+// forever.
+func (r *refResolver) resolve(node *yaml.Node, visited map[*yaml.Node]bool) error {
+	if node == nil || visited[node] {
+		return nil
+	}
+	visited[node] = true
+	defer delete(visited, node)
+
+	if node.Kind == yaml.MappingNode {
+		if ref := mappingValue(node, "$ref"); ref != nil && isFileRef(ref.Value) {
+			resolved, err := r.resolveFileRef(ref.Value)
+			if err != nil {
+				return err
+			}
+			*node = *resolved
+			return r.resolve(node, visited)
+		}
+	}
+
+	for _, child := range node.Content {
+		if err := r.resolve(child, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isFileRef reports whether ref points into another file rather than the
+// current document ("#/components/schemas/Foo").
+func isFileRef(ref string) bool {
+	return !strings.HasPrefix(ref, "#")
+}
+
+// resolveFileRef loads "file.yaml#/a/b/c", returning a deep copy of the
+// node found at the pointer so callers can safely mutate it.
+func (r *refResolver) resolveFileRef(ref string) (*yaml.Node, error) {
+	parts := strings.SplitN(ref, "#", 2)
+	filePart := parts[0]
+	pointer := ""
+	if len(parts) == 2 {
+		pointer = parts[1]
+	}
+
+	file := filepath.Join(r.baseDir, filePart)
+	key := file + "#" + pointer
+	if r.visiting[key] {
+		return nil, fmt.Errorf("circular $ref: %s", ref)
+	}
+	r.visiting[key] = true
+	defer delete(r.visiting, key)
+
+	root, ok := r.cache[file]
+	if !ok {
+		loaded, err := loadYAMLFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("load %s: %w", file, err)
+		}
+		root = mappingNode(loaded)
+		r.cache[file] = root
+	}
+
+	target, err := resolvePointer(root, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ref, err)
+	}
+
+	// Resolve refs inside the target itself before handing it back, in
+	// case it contains further cross-file references.
+	if err := r.resolve(target, make(map[*yaml.Node]bool)); err != nil {
+		return nil, err
+	}
+
+	return deepCopy(target), nil
+}
+
+// resolvePointer walks a "/a/b/c"-style JSON Pointer (the subset OpenAPI
+// $refs use: plain mapping-key segments, no "~0"/"~1" escaping) from root.
+func resolvePointer(root *yaml.Node, pointer string) (*yaml.Node, error) {
+	node := root
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return node, nil
+	}
+
+	for _, segment := range strings.Split(pointer, "/") {
+		value := mappingValue(node, segment)
+		if value == nil {
+			return nil, fmt.Errorf("pointer segment %q not found", segment)
+		}
+		node = value
+	}
+
+	return node, nil
+}
+
+// deepCopy returns a fully independent copy of node so a shared cached
+// document can be inlined at multiple call sites without aliasing.
+func deepCopy(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	copied := *node
+	if node.Content != nil {
+		copied.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			copied.Content[i] = deepCopy(child)
+		}
+	}
+	return &copied
+}
@@ -0,0 +1,276 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToJSONSchema renders spec as a standalone JSON Schema (draft 2020-12)
+// covering each path's request and response bodies, with any
+// spec.Components.Schemas inlined under "$defs" so the result validates
+// Novofon JSON-RPC payloads without an OpenAPI toolchain.
+func (spec *OpenAPISpec) ToJSONSchema() ([]byte, error) {
+	root := map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   spec.Info.Title,
+	}
+
+	if defs := spec.componentDefs(); len(defs) > 0 {
+		root["$defs"] = defs
+	}
+
+	properties := map[string]interface{}{}
+	for _, path := range spec.Paths.orderedEntries() {
+		op := operationFor(path.Item)
+		if op == nil {
+			continue
+		}
+
+		entry := map[string]interface{}{}
+		if op.RequestBody != nil {
+			if media, ok := op.RequestBody.Content["application/json"]; ok {
+				entry["request"] = schemaToJSONSchema(media.Schema)
+			}
+		}
+		if resp, ok := op.Responses["200"]; ok {
+			if media, ok := resp.Content["application/json"]; ok {
+				entry["response"] = schemaToJSONSchema(media.Schema)
+			}
+		}
+		properties[path.Path] = entry
+	}
+	root["properties"] = properties
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// componentDefs converts spec.Components.Schemas (if any) into JSON
+// Schema "$defs" entries.
+func (spec *OpenAPISpec) componentDefs() map[string]interface{} {
+	if spec.Components == nil || len(spec.Components.Schemas) == 0 {
+		return nil
+	}
+
+	defs := make(map[string]interface{}, len(spec.Components.Schemas))
+	for name, s := range spec.Components.Schemas {
+		defs[name] = schemaToJSONSchema(s)
+	}
+	return defs
+}
+
+// schemaToJSONSchema converts an OpenAPI Schema into a plain JSON Schema
+// document; the two shapes already agree on type/properties/required/
+// enum/items, so this mostly just rewrites "$ref" targets from OpenAPI's
+// "#/components/schemas/X" into JSON Schema's "#/$defs/X".
+func schemaToJSONSchema(s Schema) map[string]interface{} {
+	out := map[string]interface{}{}
+
+	if s.Ref != "" {
+		out["$ref"] = strings.Replace(s.Ref, "#/components/schemas/", "#/$defs/", 1)
+		return out
+	}
+
+	if s.Type != "" {
+		out["type"] = s.Type
+	}
+	if s.Description != "" {
+		out["description"] = s.Description
+	}
+	if len(s.Enum) > 0 {
+		out["enum"] = s.Enum
+	}
+	if s.Items != nil {
+		out["items"] = schemaToJSONSchema(*s.Items)
+	}
+	if len(s.Required) > 0 {
+		out["required"] = s.Required
+	}
+	if len(s.Properties) > 0 {
+		props := make(map[string]interface{}, len(s.Properties))
+		for name, p := range s.Properties {
+			props[name] = schemaToJSONSchema(p)
+		}
+		out["properties"] = props
+	}
+	if s.Const != nil {
+		out["const"] = s.Const
+	}
+	if len(s.AllOf) > 0 {
+		out["allOf"] = schemaListToJSONSchema(s.AllOf)
+	}
+	if len(s.OneOf) > 0 {
+		out["oneOf"] = schemaListToJSONSchema(s.OneOf)
+	}
+	if len(s.XEnumDescriptions) > 0 {
+		out["x-enum-descriptions"] = s.XEnumDescriptions
+	}
+
+	return out
+}
+
+func schemaListToJSONSchema(schemas []Schema) []interface{} {
+	out := make([]interface{}, len(schemas))
+	for i, s := range schemas {
+		out[i] = schemaToJSONSchema(s)
+	}
+	return out
+}
+
+// operationFor returns whichever HTTP method PathItem has set.
+func operationFor(item PathItem) *Operation {
+	switch {
+	case item.Post != nil:
+		return item.Post
+	case item.Get != nil:
+		return item.Get
+	case item.Put != nil:
+		return item.Put
+	case item.Delete != nil:
+		return item.Delete
+	default:
+		return nil
+	}
+}
+
+// postmanCollection is the minimal Postman v2.1 collection shape needed
+// to import every path in spec as a request.
+type postmanCollection struct {
+	Info postmanInfo   `json:"info"`
+	Item []postmanItem `json:"item"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanItem struct {
+	Name    string         `json:"name"`
+	Request postmanRequest `json:"request"`
+}
+
+type postmanRequest struct {
+	Method string          `json:"method"`
+	Header []postmanHeader `json:"header"`
+	Body   postmanBody     `json:"body"`
+	URL    postmanURL      `json:"url"`
+}
+
+type postmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanBody struct {
+	Mode string `json:"mode"`
+	Raw  string `json:"raw"`
+}
+
+type postmanURL struct {
+	Raw  string   `json:"raw"`
+	Host []string `json:"host"`
+}
+
+// ToPostmanCollection renders spec as a Postman v2.1 collection with one
+// request per path, JSON-RPC headers pre-filled, and an example body
+// built from the request schema (enum values from AllowedValues become
+// the example; everything else gets a type-appropriate placeholder).
+func (spec *OpenAPISpec) ToPostmanCollection() ([]byte, error) {
+	collection := postmanCollection{
+		Info: postmanInfo{
+			Name:   spec.Info.Title,
+			Schema: "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+	}
+
+	for _, path := range spec.Paths.orderedEntries() {
+		op := operationFor(path.Item)
+		if op == nil {
+			continue
+		}
+
+		methodName := strings.TrimPrefix(path.Path, "/")
+		body := "{}"
+		if op.RequestBody != nil {
+			if media, ok := op.RequestBody.Content["application/json"]; ok {
+				body = exampleRequestBody(methodName, media.Schema)
+			}
+		}
+
+		collection.Item = append(collection.Item, postmanItem{
+			Name: methodName,
+			Request: postmanRequest{
+				Method: strings.ToUpper(httpMethodFor(path.Item)),
+				Header: []postmanHeader{{Key: "Content-Type", Value: "application/json"}},
+				Body:   postmanBody{Mode: "raw", Raw: body},
+				URL:    postmanURL{Raw: "{{base_url}}" + path.Path, Host: []string{"{{base_url}}" + path.Path}},
+			},
+		})
+	}
+
+	return json.MarshalIndent(collection, "", "  ")
+}
+
+// httpMethodFor returns the HTTP verb PathItem is defined under.
+func httpMethodFor(item PathItem) string {
+	switch {
+	case item.Post != nil:
+		return "post"
+	case item.Get != nil:
+		return "get"
+	case item.Put != nil:
+		return "put"
+	case item.Delete != nil:
+		return "delete"
+	default:
+		return "post"
+	}
+}
+
+// exampleRequestBody builds a JSON-RPC envelope with params populated
+// from schema's properties: an enum property's example is its first
+// allowed value, everything else gets a type-appropriate default.
+func exampleRequestBody(methodName string, schema Schema) string {
+	params := map[string]interface{}{}
+	for name, prop := range schema.Properties {
+		params[name] = exampleValue(prop)
+	}
+
+	envelope := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  methodName,
+		"params":  params,
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q,"params":{}}`, methodName)
+	}
+	return string(data)
+}
+
+// exampleValue returns an enum's first allowed value, or a type-
+// appropriate placeholder when the property has no enum: 0, false, an
+// empty array or object, or the literal "string" for anything else.
+func exampleValue(schema Schema) interface{} {
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	switch schema.Type {
+	case "integer":
+		return 0
+	case "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []interface{}{}
+	case "object":
+		return map[string]interface{}{}
+	default:
+		return "string"
+	}
+}
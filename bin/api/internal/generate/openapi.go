@@ -0,0 +1,498 @@
+// Package generate turns parsed Novofon API documentation into OpenAPI 3
+// specifications. It mirrors the more feature-complete internal/generate
+// package at a scope matching what bin/api's CLI actually needs.
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xcono/novofon/bin/api/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPISpec represents an OpenAPI 3.0 specification.
+type OpenAPISpec struct {
+	OpenAPI    string            `yaml:"openapi"`
+	Info       OpenAPIInfo       `yaml:"info"`
+	Paths      Paths             `yaml:"paths"`
+	Components *Components       `yaml:"components,omitempty"`
+	XErrors    *models.ErrorInfo `yaml:"x-errors,omitempty"`
+}
+
+// OpenAPIInfo represents the info section of an OpenAPI spec.
+type OpenAPIInfo struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+// Components holds reusable objects referenced via $ref elsewhere in the spec.
+type Components struct {
+	Schemas map[string]Schema `yaml:"schemas,omitempty"`
+}
+
+// PathItem represents a path item in an OpenAPI spec.
+type PathItem struct {
+	Post   *Operation `yaml:"post,omitempty"`
+	Get    *Operation `yaml:"get,omitempty"`
+	Put    *Operation `yaml:"put,omitempty"`
+	Delete *Operation `yaml:"delete,omitempty"`
+}
+
+// Operation represents an operation in an OpenAPI spec.
+type Operation struct {
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	RequestBody *RequestBody        `yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+// RequestBody represents a request body in an OpenAPI spec.
+type RequestBody struct {
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+// Response represents a response in an OpenAPI spec.
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content,omitempty"`
+}
+
+// MediaType represents a media type in an OpenAPI spec.
+type MediaType struct {
+	Schema Schema `yaml:"schema"`
+}
+
+// Schema represents a schema in an OpenAPI spec.
+type Schema struct {
+	Type        string            `yaml:"type,omitempty"`
+	Properties  map[string]Schema `yaml:"properties,omitempty"`
+	Required    []string          `yaml:"required,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Enum        []interface{}     `yaml:"enum,omitempty"`
+	Items       *Schema           `yaml:"items,omitempty"`
+	Ref         string            `yaml:"$ref,omitempty"`
+
+	// Const pins a property to a single literal value (e.g. a JSON-RPC
+	// envelope's "method" field).
+	Const interface{} `yaml:"const,omitempty"`
+	// AllOf/OneOf let a schema extend a shared $ref (AllOf) or pick
+	// between alternative shapes (OneOf), as used by the JSON-RPC
+	// envelope's success-or-error response.
+	AllOf []Schema `yaml:"allOf,omitempty"`
+	OneOf []Schema `yaml:"oneOf,omitempty"`
+
+	// XEnumDescriptions preserves a human description for each Enum
+	// value, since OpenAPI's `enum` keyword alone can't carry one.
+	XEnumDescriptions map[string]string `yaml:"x-enum-descriptions,omitempty"`
+}
+
+// Paths is an ordered alternative to map[string]PathItem: a plain map
+// would marshal in Go's randomized iteration order, so generated and
+// bundled specs would reorder on every run even when nothing changed.
+// With preserveOrder set, MarshalYAML/MarshalJSON emit paths in the order
+// they were Set (the order methods were encountered while parsing HTML);
+// otherwise they fall back to alphabetical order, which is still
+// deterministic but doesn't track source order.
+type Paths struct {
+	entries       []pathEntry
+	index         map[string]int
+	preserveOrder bool
+}
+
+type pathEntry struct {
+	Path string
+	Item PathItem
+}
+
+// NewPaths returns an empty Paths. preserveOrder controls whether it
+// marshals in insertion (HTML discovery) order or alphabetical order.
+func NewPaths(preserveOrder bool) Paths {
+	return Paths{index: make(map[string]int), preserveOrder: preserveOrder}
+}
+
+// Set adds path/item, or replaces item in place if path was already set
+// (without changing its position).
+func (p *Paths) Set(path string, item PathItem) {
+	if p.index == nil {
+		p.index = make(map[string]int)
+	}
+	if i, ok := p.index[path]; ok {
+		p.entries[i].Item = item
+		return
+	}
+	p.index[path] = len(p.entries)
+	p.entries = append(p.entries, pathEntry{Path: path, Item: item})
+}
+
+// Len returns the number of paths.
+func (p Paths) Len() int { return len(p.entries) }
+
+// orderedEntries returns p's entries in the order they should be
+// marshaled: insertion order when preserveOrder is set, alphabetical
+// otherwise.
+func (p Paths) orderedEntries() []pathEntry {
+	if p.preserveOrder {
+		return p.entries
+	}
+	sorted := make([]pathEntry, len(p.entries))
+	copy(sorted, p.entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	return sorted
+}
+
+// MarshalYAML renders Paths as a YAML mapping.
+func (p Paths) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, e := range p.orderedEntries() {
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.Path}
+		valNode := &yaml.Node{}
+		if err := valNode.Encode(e.Item); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, keyNode, valNode)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML reads a YAML mapping into Paths, keeping the mapping's own
+// order as insertion order.
+func (p *Paths) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("paths: expected a mapping")
+	}
+	*p = NewPaths(true)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var item PathItem
+		if err := node.Content[i+1].Decode(&item); err != nil {
+			return err
+		}
+		p.Set(node.Content[i].Value, item)
+	}
+	return nil
+}
+
+// MarshalJSON renders Paths as a JSON object.
+func (p Paths) MarshalJSON() ([]byte, error) {
+	var buf strings.Builder
+	buf.WriteByte('{')
+	for i, e := range p.orderedEntries() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyJSON, err := json.Marshal(e.Path)
+		if err != nil {
+			return nil, err
+		}
+		valJSON, err := json.Marshal(e.Item)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(valJSON)
+	}
+	buf.WriteByte('}')
+	return []byte(buf.String()), nil
+}
+
+// Option configures an OpenAPIGenerator.
+type Option func(*OpenAPIGenerator)
+
+// WithPreservePathOrder makes the generator emit paths in the order
+// methods were encountered while parsing HTML, instead of alphabetical
+// order. Off by default.
+func WithPreservePathOrder(preserve bool) Option {
+	return func(g *OpenAPIGenerator) {
+		g.preservePathOrder = preserve
+	}
+}
+
+// WithJSONRPCEnvelope wraps every operation's request body in the
+// standard JSON-RPC 2.0 envelope ({jsonrpc, id, method, params}) and its
+// 200 response in a success-or-error envelope
+// ({jsonrpc, id, result} | {jsonrpc, id, error}), instead of GenerateSpec's
+// default of treating RequestParams/ResponseParams as the body directly.
+// The envelope pieces shared by every method are emitted once under
+// components/schemas and referenced with $ref.
+func WithJSONRPCEnvelope(enabled bool) Option {
+	return func(g *OpenAPIGenerator) {
+		g.jsonRPCEnvelope = enabled
+	}
+}
+
+// OpenAPIGenerator generates OpenAPI specifications from parsed API data.
+type OpenAPIGenerator struct {
+	preservePathOrder bool
+	jsonRPCEnvelope   bool
+}
+
+// NewOpenAPIGenerator creates a new OpenAPI generator.
+func NewOpenAPIGenerator(opts ...Option) *OpenAPIGenerator {
+	g := &OpenAPIGenerator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// GenerateSpec builds an OpenAPI 3.0 spec for a single parsed method.
+func (g *OpenAPIGenerator) GenerateSpec(apiData *models.APIData) (*OpenAPISpec, error) {
+	if apiData == nil || apiData.MethodInfo == nil {
+		return nil, fmt.Errorf("invalid API data: method info is required")
+	}
+
+	methodInfo := apiData.MethodInfo
+	title := methodInfo.Title
+	if title == "" {
+		title = fmt.Sprintf("Novofon API - %s", methodInfo.Name)
+	}
+
+	description := methodInfo.Description
+	if description == "" {
+		description = fmt.Sprintf("API endpoint for %s", methodInfo.Name)
+	}
+
+	spec := &OpenAPISpec{
+		OpenAPI: "3.0.0",
+		Info: OpenAPIInfo{
+			Title:       title,
+			Version:     "1.0.0",
+			Description: description,
+		},
+		Paths: NewPaths(g.preservePathOrder),
+	}
+
+	if apiData.ErrorInfo != nil && len(apiData.ErrorInfo.Errors) > 0 {
+		spec.XErrors = apiData.ErrorInfo
+	}
+
+	path := fmt.Sprintf("/%s", methodInfo.Name)
+	var operation *Operation
+	if g.jsonRPCEnvelope {
+		operation = g.generateJSONRPCOperation(apiData)
+		spec.Components = &Components{Schemas: jsonRPCEnvelopeSchemas(apiData.ErrorInfo)}
+	} else {
+		operation = g.generateOperation(apiData)
+	}
+
+	pathItem := PathItem{}
+	switch strings.ToLower(methodInfo.HTTPMethod) {
+	case "get":
+		pathItem.Get = operation
+	case "put":
+		pathItem.Put = operation
+	case "delete":
+		pathItem.Delete = operation
+	default:
+		pathItem.Post = operation // Default to POST for JSON-RPC
+	}
+
+	spec.Paths.Set(path, pathItem)
+
+	return spec, nil
+}
+
+// generateOperation builds the request/response schema for one method.
+func (g *OpenAPIGenerator) generateOperation(apiData *models.APIData) *Operation {
+	methodInfo := apiData.MethodInfo
+
+	op := &Operation{
+		Summary:     methodInfo.Title,
+		Description: methodInfo.Description,
+		Responses:   map[string]Response{},
+	}
+
+	if len(apiData.RequestParams) > 0 {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				"application/json": {Schema: g.generateParamsSchema(apiData.RequestParams)},
+			},
+		}
+	}
+
+	op.Responses["200"] = Response{
+		Description: "Successful response",
+		Content: map[string]MediaType{
+			"application/json": {Schema: g.generateParamsSchema(apiData.ResponseParams)},
+		},
+	}
+
+	return op
+}
+
+// generateJSONRPCOperation is generateOperation's JSON-RPC-envelope
+// variant: the request body extends the shared JsonRpcRequest schema with
+// a const-pinned "method" and this method's params schema, and the 200
+// response offers either a JsonRpcSuccess extended with this method's
+// result schema, or a JsonRpcError.
+func (g *OpenAPIGenerator) generateJSONRPCOperation(apiData *models.APIData) *Operation {
+	methodInfo := apiData.MethodInfo
+
+	requestSchema := Schema{
+		AllOf: []Schema{
+			{Ref: "#/components/schemas/JsonRpcRequest"},
+			{Properties: map[string]Schema{
+				"method": {Const: methodInfo.Name},
+				"params": g.generateParamsSchema(apiData.RequestParams),
+			}},
+		},
+	}
+
+	successSchema := Schema{
+		AllOf: []Schema{
+			{Ref: "#/components/schemas/JsonRpcSuccess"},
+			{Properties: map[string]Schema{
+				"result": g.generateParamsSchema(apiData.ResponseParams),
+			}},
+		},
+	}
+	errorSchema := Schema{Ref: "#/components/schemas/JsonRpcError"}
+
+	return &Operation{
+		Summary:     methodInfo.Title,
+		Description: methodInfo.Description,
+		RequestBody: &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: requestSchema}},
+		},
+		Responses: map[string]Response{
+			"200": {
+				Description: "JSON-RPC response: either a result or an error",
+				Content: map[string]MediaType{
+					"application/json": {Schema: Schema{OneOf: []Schema{successSchema, errorSchema}}},
+				},
+			},
+		},
+	}
+}
+
+// jsonRPCEnvelopeSchemas builds the components/schemas shared by every
+// JSON-RPC-envelope operation: the request/success envelopes (identical
+// across every method), plus an error envelope and error-code enum
+// specific to this method's documented errors.
+func jsonRPCEnvelopeSchemas(errorInfo *models.ErrorInfo) map[string]Schema {
+	schemas := map[string]Schema{
+		"JsonRpcRequest": {
+			Type: "object",
+			Properties: map[string]Schema{
+				"jsonrpc": {Type: "string", Const: "2.0"},
+				"id":      {Type: "integer"},
+				"method":  {Type: "string"},
+				"params":  {Type: "object"},
+			},
+			Required: []string{"jsonrpc", "id", "method"},
+		},
+		"JsonRpcSuccess": {
+			Type: "object",
+			Properties: map[string]Schema{
+				"jsonrpc": {Type: "string", Const: "2.0"},
+				"id":      {Type: "integer"},
+				"result":  {Type: "object"},
+			},
+			Required: []string{"jsonrpc", "id", "result"},
+		},
+		"JsonRpcError": {
+			Type: "object",
+			Properties: map[string]Schema{
+				"jsonrpc": {Type: "string", Const: "2.0"},
+				"id":      {Type: "integer"},
+				"error":   {Ref: "#/components/schemas/JsonRpcErrorObject"},
+			},
+			Required: []string{"jsonrpc", "id", "error"},
+		},
+		"JsonRpcErrorObject": jsonRPCErrorObjectSchema(errorInfo),
+	}
+
+	return schemas
+}
+
+// jsonRPCErrorObjectSchema builds the {code, message, data} error object
+// schema, with errorInfo's documented errors materialized as an enum on
+// "code" and their descriptions preserved via x-enum-descriptions (since
+// OpenAPI's enum keyword can't carry one itself).
+func jsonRPCErrorObjectSchema(errorInfo *models.ErrorInfo) Schema {
+	schema := Schema{
+		Type: "object",
+		Properties: map[string]Schema{
+			"code":    {Type: "integer"},
+			"message": {Type: "string"},
+			"data":    {},
+		},
+		Required: []string{"code", "message"},
+	}
+
+	if errorInfo == nil || len(errorInfo.Errors) == 0 {
+		return schema
+	}
+
+	codeSchema := schema.Properties["code"]
+	descriptions := make(map[string]string, len(errorInfo.Errors))
+	for _, e := range errorInfo.Errors {
+		codeSchema.Enum = append(codeSchema.Enum, e.Code)
+		descriptions[e.Code] = strings.TrimSpace(e.Mnemonic + ": " + e.Description)
+	}
+	codeSchema.XEnumDescriptions = descriptions
+	schema.Properties["code"] = codeSchema
+
+	return schema
+}
+
+// generateParamsSchema builds an object schema from a set of parsed
+// parameters, sorted by name for deterministic output (params has no
+// discovery-order tracking in bin/api's models.APIData).
+func (g *OpenAPIGenerator) generateParamsSchema(params map[string]*models.Parameter) Schema {
+	schema := Schema{Type: "object", Properties: map[string]Schema{}}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		param := params[name]
+		propSchema := Schema{Type: mapParamType(param.Type), Description: param.Description}
+		if param.AllowedValues != "" {
+			for _, v := range strings.Split(param.AllowedValues, ",") {
+				propSchema.Enum = append(propSchema.Enum, strings.TrimSpace(v))
+			}
+		}
+		schema.Properties[name] = propSchema
+		if param.Required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+func mapParamType(paramType string) string {
+	switch strings.ToLower(paramType) {
+	case "int", "integer":
+		return "integer"
+	case "float", "double", "number":
+		return "number"
+	case "bool", "boolean":
+		return "boolean"
+	case "array", "list":
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+// ToYAML renders spec as YAML.
+func (spec *OpenAPISpec) ToYAML() ([]byte, error) {
+	return yaml.Marshal(spec)
+}
+
+// ToJSON renders spec as indented JSON.
+func (spec *OpenAPISpec) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(spec, "", "  ")
+}
@@ -0,0 +1,73 @@
+package generate
+
+import "gopkg.in/yaml.v3"
+
+// WithoutExtensions returns a deep-cloned spec with every vendor
+// extension key removed: by default any key starting with "x-" anywhere
+// in the document (paths, components, nested schema objects, ...); pass
+// one or more prefixes to remove only matching extensions instead.
+// Implemented by walking the marshaled yaml.Node tree rather than the
+// typed struct, so every x-* key is reached wherever it is nested
+// (Components.Schemas, Paths, ...) without clearing each extension field
+// of each type by hand.
+func (spec *OpenAPISpec) WithoutExtensions(prefixes ...string) *OpenAPISpec {
+	if len(prefixes) == 0 {
+		prefixes = []string{"x-"}
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return spec
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return spec
+	}
+
+	stripExtensions(&node, prefixes)
+
+	stripped, err := yaml.Marshal(&node)
+	if err != nil {
+		return spec
+	}
+
+	var out OpenAPISpec
+	if err := yaml.Unmarshal(stripped, &out); err != nil {
+		return spec
+	}
+	return &out
+}
+
+// stripExtensions removes any mapping entry whose key starts with one of
+// prefixes, recursing into every remaining value.
+func stripExtensions(node *yaml.Node, prefixes []string) {
+	if node == nil {
+		return
+	}
+
+	if node.Kind == yaml.MappingNode {
+		var kept []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if hasAnyPrefix(key.Value, prefixes) {
+				continue
+			}
+			kept = append(kept, key, value)
+		}
+		node.Content = kept
+	}
+
+	for _, child := range node.Content {
+		stripExtensions(child, prefixes)
+	}
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if len(s) >= len(p) && s[:len(p)] == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcono/novofon/bin/api/internal/models"
+)
+
+func TestOpenAPISpec_WithoutExtensions(t *testing.T) {
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "get.balance", HTTPMethod: "get"},
+		RequestParams: map[string]*models.Parameter{
+			"id": {Name: "id", Type: "string", Required: true},
+		},
+		ErrorInfo: &models.ErrorInfo{
+			Errors: []models.Error{{Code: "40", Mnemonic: "invalid_params", Description: "bad input"}},
+		},
+	}
+
+	generator := NewOpenAPIGenerator()
+	spec, err := generator.GenerateSpec(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSpec failed: %v", err)
+	}
+
+	stripped := spec.WithoutExtensions()
+
+	data, err := stripped.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+
+	if strings.Contains(string(data), "x-errors") {
+		t.Errorf("expected x-errors to be stripped, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "get.balance") {
+		t.Errorf("expected non-extension content to survive stripping, got:\n%s", data)
+	}
+
+	// The original spec must be untouched.
+	originalData, err := spec.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	if !strings.Contains(string(originalData), "x-errors") {
+		t.Errorf("expected original spec to still have x-errors, got:\n%s", originalData)
+	}
+}
+
+func TestOpenAPISpec_WithoutExtensions_CustomPrefix(t *testing.T) {
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "get.balance", HTTPMethod: "get"},
+		ErrorInfo: &models.ErrorInfo{
+			Errors: []models.Error{{Code: "40", Mnemonic: "invalid_params", Description: "bad input"}},
+		},
+	}
+
+	generator := NewOpenAPIGenerator(WithJSONRPCEnvelope(true))
+	spec, err := generator.GenerateSpec(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSpec failed: %v", err)
+	}
+
+	originalData, err := spec.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	if !strings.Contains(string(originalData), "x-enum-descriptions") {
+		t.Fatalf("expected generated spec to contain x-enum-descriptions before stripping, got:\n%s", originalData)
+	}
+
+	stripped := spec.WithoutExtensions("x-enum-descriptions")
+
+	data, err := stripped.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+
+	if strings.Contains(string(data), "x-enum-descriptions") {
+		t.Errorf("expected x-enum-descriptions to be stripped, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "JsonRpcRequest") {
+		t.Errorf("expected shared envelope schemas to survive stripping, got:\n%s", data)
+	}
+}
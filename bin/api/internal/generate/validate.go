@@ -0,0 +1,91 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ValidationErrors collects every problem found by Validate: OpenAPI
+// document errors and schema/example roundtrip mismatches alike, so
+// callers can report them together without caring which check produced
+// which message.
+type ValidationErrors []string
+
+// Validate loads an emitted OpenAPI document with kin-openapi and runs two
+// checks against it: the document's own openapi3.T.Validate, and a
+// schema-example roundtrip for every operation's request body, which
+// catches schemas the HTML parser produced that don't actually fit
+// OpenAPI (e.g. an AllowedValues enum with mixed types) even when the
+// document itself is structurally valid.
+func Validate(ctx context.Context, content []byte) ValidationErrors {
+	var errs ValidationErrors
+
+	doc, err := openapi3.NewLoader().LoadFromData(content)
+	if err != nil {
+		return append(errs, fmt.Sprintf("load: %v", err))
+	}
+
+	if err := doc.Validate(ctx); err != nil {
+		errs = append(errs, fmt.Sprintf("validate: %v", err))
+	}
+
+	if doc.Paths == nil {
+		return errs
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			if op.RequestBody == nil || op.RequestBody.Value == nil {
+				continue
+			}
+			media := op.RequestBody.Value.Content.Get("application/json")
+			if media == nil || media.Schema == nil || media.Schema.Value == nil {
+				continue
+			}
+
+			example := exampleFromSchema(media.Schema.Value)
+			if err := media.Schema.Value.VisitJSON(example); err != nil {
+				errs = append(errs, fmt.Sprintf("%s %s: generated example failed schema roundtrip: %v", method, path, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// exampleFromSchema builds a synthetic JSON value satisfying s well enough
+// to roundtrip through its own VisitJSON: an enum's first value, or a
+// type-appropriate default, recursing into object properties and array
+// items.
+func exampleFromSchema(s *openapi3.Schema) interface{} {
+	if s == nil {
+		return nil
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+
+	switch {
+	case s.Type.Is("object"):
+		obj := map[string]interface{}{}
+		for name, prop := range s.Properties {
+			if prop.Value != nil {
+				obj[name] = exampleFromSchema(prop.Value)
+			}
+		}
+		return obj
+	case s.Type.Is("array"):
+		if s.Items != nil && s.Items.Value != nil {
+			return []interface{}{exampleFromSchema(s.Items.Value)}
+		}
+		return []interface{}{}
+	case s.Type.Is("integer"), s.Type.Is("number"):
+		return 0
+	case s.Type.Is("boolean"):
+		return false
+	default:
+		return "string"
+	}
+}
@@ -1,26 +1,48 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/xcono/novofon/bin/api/internal/bundle"
 	"github.com/xcono/novofon/bin/api/internal/generate"
 	"github.com/xcono/novofon/bin/api/internal/parse"
-	"gopkg.in/yaml.v3"
 )
 
+// outputFormats are the --format values supported alongside the default
+// OpenAPI YAML output.
+var outputFormats = map[string]string{
+	"openapi":    "yaml",
+	"jsonschema": "schema.json",
+	"postman":    "postman_collection.json",
+}
+
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <input-dir> <output-dir>\n", os.Args[0])
+	format := flag.String("format", "openapi", "output format: openapi, jsonschema, or postman")
+	stripExtensions := flag.Bool("strip-extensions", false, "remove x-* vendor extensions (e.g. x-errors) before writing output")
+	strict := flag.Bool("strict", false, "exit non-zero if any generated OpenAPI document fails validation")
+	flag.Parse()
+
+	if _, ok := outputFormats[*format]; !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown --format %q (want openapi, jsonschema, or postman)\n", *format)
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [--format=openapi|jsonschema|postman] <input-dir> <output-dir>\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  input-dir:  Directory containing HTML files to parse\n")
-		fmt.Fprintf(os.Stderr, "  output-dir: Directory to write OpenAPI YAML files\n")
+		fmt.Fprintf(os.Stderr, "  output-dir: Directory to write generated files\n")
 		os.Exit(1)
 	}
 
-	inputDir := os.Args[1]
-	outputDir := os.Args[2]
+	inputDir := args[0]
+	outputDir := args[1]
 
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -40,6 +62,7 @@ func main() {
 
 	processed := 0
 	errors := 0
+	validationErrors := 0
 
 	for _, htmlFile := range htmlFiles {
 		fmt.Printf("Processing: %s\n", htmlFile)
@@ -68,26 +91,50 @@ func main() {
 			continue
 		}
 
-		// Write output file
-		outputFile := getOutputFileName(htmlFile, outputDir)
-		yamlContent, err := openAPISpec.ToYAML()
+		if *stripExtensions {
+			openAPISpec = openAPISpec.WithoutExtensions()
+		}
+
+		// Render in the requested format and write the output file
+		var content []byte
+		switch *format {
+		case "jsonschema":
+			content, err = openAPISpec.ToJSONSchema()
+		case "postman":
+			content, err = openAPISpec.ToPostmanCollection()
+		default:
+			content, err = openAPISpec.ToYAML()
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error converting to YAML for %s: %v\n", htmlFile, err)
+			fmt.Fprintf(os.Stderr, "Error converting to %s for %s: %v\n", *format, htmlFile, err)
 			errors++
 			continue
 		}
 
-		if err := os.WriteFile(outputFile, []byte(yamlContent), 0644); err != nil {
+		outputFile := getOutputFileName(htmlFile, outputDir, outputFormats[*format])
+		if err := os.WriteFile(outputFile, content, 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outputFile, err)
 			errors++
 			continue
 		}
 
+		// Validation only applies to the OpenAPI document itself; the
+		// jsonschema/postman outputs are derived formats with no
+		// kin-openapi representation to load.
+		if *format == "openapi" {
+			if issues := generate.Validate(context.Background(), content); len(issues) > 0 {
+				for _, issue := range issues {
+					fmt.Fprintf(os.Stderr, "Validation error in %s: %s\n", outputFile, issue)
+				}
+				validationErrors += len(issues)
+			}
+		}
+
 		fmt.Printf("Generated: %s\n", outputFile)
 		processed++
 	}
 
-	fmt.Printf("\nSummary: %d files processed, %d errors\n", processed, errors)
+	fmt.Printf("\nSummary: %d files processed, %d errors, %d validation errors\n", processed, errors, validationErrors)
 
 	// Only exit with error if there were critical failures (like file read errors)
 	// Parsing errors from index pages are expected and shouldn't cause failure
@@ -97,10 +144,18 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Bundle individual spec files into unified API specs
-	if err := bundleAPISpecs(outputDir); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to bundle API specs: %v\n", err)
-		// Don't fail the entire process for bundling errors
+	// Bundling combines per-method OpenAPI YAML files; it doesn't apply to
+	// the single-document jsonschema/postman outputs.
+	if *format == "openapi" {
+		if err := bundleAPISpecs(outputDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to bundle API specs: %v\n", err)
+			// Don't fail the entire process for bundling errors
+		}
+	}
+
+	if *strict && validationErrors > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d validation errors found (--strict)\n", validationErrors)
+		os.Exit(1)
 	}
 }
 
@@ -142,7 +197,7 @@ func findHTMLFiles(dir string) ([]string, error) {
 	return htmlFiles, err
 }
 
-func getOutputFileName(htmlFile, outputDir string) string {
+func getOutputFileName(htmlFile, outputDir, ext string) string {
 	// Extract relative path from HTML file starting from the API type folder
 	// e.g., temp-html/data_api/authentication/login_user/index.html
 	// Should extract: authentication/login_user/index.html
@@ -163,8 +218,8 @@ func getOutputFileName(htmlFile, outputDir string) string {
 		fileName := strings.ReplaceAll(relPath, "/", ".")
 		fileName = strings.TrimSuffix(fileName, ".html")
 		fileName = strings.TrimSuffix(fileName, ".index")
-		if !strings.HasSuffix(fileName, ".yaml") {
-			fileName += ".yaml"
+		if !strings.HasSuffix(fileName, "."+ext) {
+			fileName += "." + ext
 		}
 		return filepath.Join(outputDir, fileName)
 	}
@@ -179,9 +234,9 @@ func getOutputFileName(htmlFile, outputDir string) string {
 	// Remove .index suffix if present for cleaner naming
 	fileName = strings.TrimSuffix(fileName, ".index")
 
-	// Ensure it ends with .yaml
-	if !strings.HasSuffix(fileName, ".yaml") {
-		fileName += ".yaml"
+	// Ensure it ends with the requested format's extension
+	if !strings.HasSuffix(fileName, "."+ext) {
+		fileName += "." + ext
 	}
 
 	return filepath.Join(outputDir, fileName)
@@ -199,7 +254,9 @@ func bundleAPISpecs(outputDir string) error {
 		return fmt.Errorf("no YAML files found to bundle")
 	}
 
-	// Group files by API type (data vs calls)
+	// Group files by API type (data vs calls), in a deterministic
+	// (lexical) order so the bundled path order is reproducible across
+	// runs regardless of filepath.Walk's own ordering guarantees.
 	dataFiles := []string{}
 	callFiles := []string{}
 
@@ -211,6 +268,8 @@ func bundleAPISpecs(outputDir string) error {
 			callFiles = append(callFiles, file)
 		}
 	}
+	sort.Strings(dataFiles)
+	sort.Strings(callFiles)
 
 	// Bundle data API files - place at top level of outputDir parent
 	if len(dataFiles) > 0 {
@@ -260,95 +319,18 @@ func findYAMLFiles(dir string) ([]string, error) {
 	return yamlFiles, err
 }
 
-// createBundledSpec creates a single OpenAPI spec from multiple individual specs
+// createBundledSpec merges inputFiles into a single OpenAPI spec using
+// bundle.Bundle, which deduplicates identical schemas by content hash
+// (rather than this function's old first-file-wins map merge), merges
+// rather than drops paths that collide across files, resolves cross-file
+// $refs, and deduplicates x-errors by code+mnemonic.
 func createBundledSpec(inputFiles []string, outputFile, title, description string) error {
-	// Create the base bundled spec structure
-	bundledSpec := map[string]interface{}{
-		"openapi": "3.0.0",
-		"info": map[string]interface{}{
-			"title":       title,
-			"version":     "1.0.0",
-			"description": description,
-		},
-		"paths": make(map[string]interface{}),
-	}
-
-	// Process each input file
-	for _, inputFile := range inputFiles {
-		content, err := os.ReadFile(inputFile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to read %s: %v\n", inputFile, err)
-			continue
-		}
-
-		var spec map[string]interface{}
-		if err := yaml.Unmarshal(content, &spec); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to parse %s: %v\n", inputFile, err)
-			continue
-		}
-
-		// Merge paths from this spec into the bundled spec
-		if paths, ok := spec["paths"].(map[string]interface{}); ok {
-			bundledPaths := bundledSpec["paths"].(map[string]interface{})
-			for path, pathItem := range paths {
-				if _, exists := bundledPaths[path]; exists {
-					fmt.Fprintf(os.Stderr, "Warning: Path %s already exists, skipping from %s\n", path, inputFile)
-					continue
-				}
-				bundledPaths[path] = pathItem
-			}
-		}
-
-		// Merge components if they exist
-		if components, ok := spec["components"].(map[string]interface{}); ok {
-			if bundledSpec["components"] == nil {
-				bundledSpec["components"] = make(map[string]interface{})
-			}
-			bundledComponents := bundledSpec["components"].(map[string]interface{})
-
-			for componentType, componentData := range components {
-				if bundledComponents[componentType] == nil {
-					bundledComponents[componentType] = make(map[string]interface{})
-				}
-				targetComponents := bundledComponents[componentType].(map[string]interface{})
-
-				if sourceComponents, ok := componentData.(map[string]interface{}); ok {
-					for name, component := range sourceComponents {
-						if _, exists := targetComponents[name]; !exists {
-							targetComponents[name] = component
-						}
-					}
-				}
-			}
-		}
-
-		// Merge x-errors if they exist
-		if xerrors, ok := spec["x-errors"]; ok {
-			if bundledSpec["x-errors"] == nil {
-				bundledSpec["x-errors"] = map[string]interface{}{
-					"errors": []interface{}{},
-				}
-			}
-
-			if bundledErrors, ok := bundledSpec["x-errors"].(map[string]interface{}); ok {
-				if sourceErrors, ok := xerrors.(map[string]interface{}); ok {
-					if sourceErrorList, ok := sourceErrors["errors"].([]interface{}); ok {
-						if bundledErrorList, ok := bundledErrors["errors"].([]interface{}); ok {
-							// Avoid duplicate errors
-							for _, sourceError := range sourceErrorList {
-								bundledErrors["errors"] = append(bundledErrorList, sourceError)
-							}
-						}
-					}
-				}
-			}
-		}
-	}
-
-	// Write the bundled spec
-	bundledContent, err := yaml.Marshal(bundledSpec)
+	bundledContent, err := bundle.Bundle(inputFiles, bundle.Options{
+		Title:       title,
+		Description: description,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal bundled spec: %w", err)
+		return fmt.Errorf("failed to bundle specs: %w", err)
 	}
 
 	if err := os.WriteFile(outputFile, bundledContent, 0644); err != nil {
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xcono/novofon/internal/lint"
+	"gopkg.in/yaml.v3"
+)
+
+// Target is one named build target from novofon.yaml: a self-contained
+// description of how to bundle a subset of the generated per-method specs.
+type Target struct {
+	// Name is filled in from the targets map key, not read from YAML.
+	Name string `yaml:"-"`
+
+	// Input is one or more globs (matched against the per-method YAML
+	// filename, e.g. "data.contact.*.yaml") selecting which generated
+	// files this target bundles. Empty means "everything".
+	Input []string `yaml:"input"`
+
+	GroupingStrategy   string            `yaml:"grouping_strategy"`
+	DomainMappings     map[string]string `yaml:"domain_mappings"`
+	OutputPathTemplate string            `yaml:"output_path_template"`
+	IncludeDomains     []string          `yaml:"include_domains"`
+	ExcludeDomains     []string          `yaml:"exclude_domains"`
+
+	Info    TargetInfo `yaml:"info"`
+	Servers []string   `yaml:"servers"`
+
+	// Flatten inlines every internal $ref into its use site (see
+	// FlattenTransform). RemoveExtensions strips keys matching
+	// ExtensionGlobs (DefaultExtensionGlobs when empty) from every node
+	// (see RemoveExtensionsTransform).
+	Flatten          bool     `yaml:"flatten"`
+	RemoveExtensions bool     `yaml:"remove_extensions"`
+	ExtensionGlobs   []string `yaml:"extension_globs"`
+}
+
+// TargetInfo overrides the OpenAPI info block for a target's bundles.
+type TargetInfo struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+// BuildConfig is the top-level shape of novofon.yaml.
+type BuildConfig struct {
+	Targets map[string]Target `yaml:"targets"`
+}
+
+// LoadBuildConfig reads and parses a novofon.yaml build-target config.
+func LoadBuildConfig(path string) (*BuildConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read build config: %w", err)
+	}
+
+	var cfg BuildConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse build config: %w", err)
+	}
+
+	for name, target := range cfg.Targets {
+		target.Name = name
+		cfg.Targets[name] = target
+	}
+
+	return &cfg, nil
+}
+
+// ResolveTargets expands name into the Targets it selects: a declared
+// target's own name, or "all", which expands to every declared target
+// (in unspecified order, like ranging a map).
+func (c *BuildConfig) ResolveTargets(name string) ([]Target, error) {
+	if name == "all" {
+		targets := make([]Target, 0, len(c.Targets))
+		for _, t := range c.Targets {
+			targets = append(targets, t)
+		}
+		return targets, nil
+	}
+
+	target, ok := c.Targets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown target %q", name)
+	}
+	return []Target{target}, nil
+}
+
+// defaultBuildConfig reproduces the bundling behavior this CLI had before
+// novofon.yaml existed, as the single "all" target used when no config file
+// is present.
+func defaultBuildConfig() *BuildConfig {
+	return &BuildConfig{
+		Targets: map[string]Target{
+			"all": {
+				Name:             "all",
+				GroupingStrategy: "domain",
+				DomainMappings: map[string]string{
+					"ca_deal":         "deal",
+					"ca_contact":      "contact",
+					"ca_employee":     "employee",
+					"ca_sales_funnel": "sales_funnel",
+				},
+			},
+		},
+	}
+}
+
+// toBundlingConfig converts a Target plus the shared outputDir/linters into
+// the BundlingConfig bundleAPISpecs expects.
+func (t Target) toBundlingConfig(outputDir string, linters []lint.LinterConfig) BundlingConfig {
+	var transforms []Transform
+	if t.Flatten {
+		transforms = append(transforms, FlattenTransform{})
+	}
+	if t.RemoveExtensions {
+		transforms = append(transforms, RemoveExtensionsTransform{Globs: t.ExtensionGlobs})
+	}
+
+	return BundlingConfig{
+		GroupingStrategy:   defaultString(t.GroupingStrategy, "domain"),
+		MinFilesPerDomain:  1,
+		DomainMappings:     t.DomainMappings,
+		OutputDir:          outputDir,
+		OutputPathTemplate: t.OutputPathTemplate,
+		IncludeDomains:     t.IncludeDomains,
+		ExcludeDomains:     t.ExcludeDomains,
+		Version:            defaultString(t.Info.Version, "1.0.0"),
+		Servers:            t.Servers,
+		Linters:            linters,
+		Transforms:         transforms,
+	}
+}
+
+func defaultString(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// filterByInputGlobs keeps only the yamlFiles whose base name matches at
+// least one of globs; an empty globs list matches everything.
+func filterByInputGlobs(yamlFiles []string, globs []string) []string {
+	if len(globs) == 0 {
+		return yamlFiles
+	}
+
+	var matched []string
+	for _, file := range yamlFiles {
+		base := filepath.Base(file)
+		for _, glob := range globs {
+			if ok, _ := filepath.Match(glob, base); ok {
+				matched = append(matched, file)
+				break
+			}
+		}
+	}
+	return matched
+}
@@ -0,0 +1,119 @@
+package batch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/xcono/novofon/internal/generate"
+	"github.com/xcono/novofon/internal/models"
+	"github.com/xcono/novofon/internal/validate"
+)
+
+// cacheFormatVersion is folded into every cache key. Bump it whenever a
+// change to parsing, OpenAPI generation, or validation would make
+// previously cached results stale even though the source HTML didn't
+// change.
+const cacheFormatVersion = "1"
+
+// cacheEntry is the serialized form of a BatchResult worth reusing across
+// runs: everything processFile derives from a file's content, minus
+// per-run bookkeeping like ProcessTime or CacheHit.
+type cacheEntry struct {
+	APIData     *models.APIData            `json:"api_data,omitempty"`
+	OpenAPISpec *generate.OpenAPISpec      `json:"openapi_spec,omitempty"`
+	Validation  *validate.ValidationResult `json:"validation,omitempty"`
+}
+
+// batchCache is a SHA-256(file content)+cacheFormatVersion -> cacheEntry
+// map persisted as CacheDir/cache.json, so a batch re-run over
+// mostly-unchanged HTML can skip re-parsing and re-validating every file.
+type batchCache struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	dirty   bool
+}
+
+func loadBatchCache(dir string) (*batchCache, error) {
+	c := &batchCache{dir: dir, entries: make(map[string]cacheEntry)}
+	if dir == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "cache.json"))
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// save writes the cache back to CacheDir/cache.json if anything changed
+// since it was loaded. A no-op when the cache has no directory.
+func (c *batchCache) save() error {
+	if c == nil || c.dir == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(c.dir, "cache.json"), data, 0644); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+func (c *batchCache) lookup(key string) (cacheEntry, bool) {
+	if c == nil {
+		return cacheEntry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *batchCache) store(key string, entry cacheEntry) {
+	if c == nil || c.dir == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+	c.dirty = true
+}
+
+// cacheKey hashes file content together with cacheFormatVersion, so a
+// processor code change invalidates every existing cache entry without
+// needing to touch any HTML file.
+func cacheKey(content []byte) string {
+	h := sha256.New()
+	h.Write(content)
+	h.Write([]byte(cacheFormatVersion))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,57 @@
+package batch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// brotliDecompress decompresses brotli-encoded content. It's nil unless
+// this binary was built with -tags brotli, which registers a decoder
+// backed by andybalholm/brotli via that build's init(); without the tag,
+// ".html.br" files are treated as not found rather than failing the build.
+var brotliDecompress func([]byte) ([]byte, error)
+
+// isHTMLPath reports whether path is a (possibly compressed) HTML
+// document findHTMLFiles should pick up: ".html", ".html.gz", or (when
+// built with -tags brotli) ".html.br".
+func isHTMLPath(path string) bool {
+	lower := strings.ToLower(path)
+	if strings.HasSuffix(lower, ".html") || strings.HasSuffix(lower, ".html.gz") {
+		return true
+	}
+	return strings.HasSuffix(lower, ".html.br") && brotliDecompress != nil
+}
+
+// stripIndexSuffix trims any compression extension off path so callers
+// can apply the same "is this a root index.html?" check regardless of
+// whether the file is compressed.
+func stripIndexSuffix(name string) string {
+	name = strings.TrimSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".br")
+	return name
+}
+
+// decompressHTML returns content decompressed according to path's
+// extension, or content unchanged for a plain ".html" file.
+func decompressHTML(path string, content []byte) ([]byte, error) {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".html.gz"):
+		r, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("open gzip: %w", err)
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	case strings.HasSuffix(lower, ".html.br"):
+		if brotliDecompress == nil {
+			return nil, fmt.Errorf("brotli support not built in (rebuild with -tags brotli)")
+		}
+		return brotliDecompress(content)
+	default:
+		return content, nil
+	}
+}
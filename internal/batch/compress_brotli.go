@@ -0,0 +1,18 @@
+//go:build brotli
+
+package batch
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// init registers brotliDecompress when built with -tags brotli, enabling
+// ".html.br" support in findHTMLFiles/decompressHTML.
+func init() {
+	brotliDecompress = func(content []byte) ([]byte, error) {
+		return io.ReadAll(brotli.NewReader(bytes.NewReader(content)))
+	}
+}
@@ -0,0 +1,200 @@
+package batch
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// corpusManifestEntry records where one corpus entry's HTML came from,
+// stored in manifest.json alongside the pages themselves.
+type corpusManifestEntry struct {
+	URL    string `json:"url,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+// corpusManifest maps a tar entry name (e.g. "start.simple_call.html") to
+// its corpusManifestEntry.
+type corpusManifest map[string]corpusManifestEntry
+
+// corpusDocument is one HTML page read out of a corpus archive, paired
+// with the source label ProcessCorpus's results should report.
+type corpusDocument struct {
+	source  string
+	content []byte
+}
+
+// ProcessCorpus processes a novofon-docs-YYYYMMDD.tar.gz style corpus: a
+// gzip-compressed tar archive of HTML pages plus a manifest.json mapping
+// each entry name to the URL or method name it came from, so the entire
+// Novofon docs tree can ship (and be reprocessed) as one ~10x-smaller file
+// instead of hundreds of raw HTML files.
+func (bp *BatchProcessor) ProcessCorpus(ctx context.Context, path string) (*BatchReport, error) {
+	startTime := time.Now()
+
+	docs, err := readCorpus(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %w", err)
+	}
+
+	if bp.options.Verbose {
+		bp.logger.Info("read corpus", "path", path, "documents", len(docs))
+	}
+
+	if bp.options.OutputDir != "" {
+		if err := os.MkdirAll(bp.options.OutputDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	results := bp.processCorpusDocuments(ctx, docs)
+	report := bp.generateReport(startTime, results)
+
+	if bp.options.GenerateReport {
+		if err := bp.saveReport(report); err != nil {
+			return nil, fmt.Errorf("failed to save report: %w", err)
+		}
+	}
+
+	if err := bp.cache.save(); err != nil {
+		bp.logger.Warn("batch cache save failed", "dir", bp.options.CacheDir, "error", err)
+	}
+
+	return report, nil
+}
+
+// readCorpus extracts every HTML entry from path's tar.gz archive,
+// labeling each with manifest.json's URL (falling back to its method name,
+// then its raw entry name) if a manifest is present.
+func readCorpus(path string) ([]corpusDocument, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("open gzip: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	var manifest corpusManifest
+	pages := make(map[string][]byte)
+	var order []string
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read tar: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read entry %s: %w", header.Name, err)
+		}
+
+		if header.Name == "manifest.json" {
+			manifest = make(corpusManifest)
+			if err := json.Unmarshal(content, &manifest); err != nil {
+				return nil, fmt.Errorf("parse manifest.json: %w", err)
+			}
+			continue
+		}
+
+		pages[header.Name] = content
+		order = append(order, header.Name)
+	}
+
+	docs := make([]corpusDocument, 0, len(order))
+	for _, name := range order {
+		docs = append(docs, corpusDocument{source: corpusSource(manifest, name), content: pages[name]})
+	}
+	return docs, nil
+}
+
+// corpusSource returns the label a corpus entry's results should carry:
+// manifest's URL for name if present, else its recorded method name, else
+// the raw entry name.
+func corpusSource(manifest corpusManifest, name string) string {
+	if manifest != nil {
+		if entry, ok := manifest[name]; ok {
+			if entry.URL != "" {
+				return entry.URL
+			}
+			if entry.Method != "" {
+				return entry.Method
+			}
+		}
+	}
+	return name
+}
+
+// processCorpusDocuments runs docs through processDocument concurrently,
+// one worker goroutine per MaxWorkers, the same fan-out shape
+// processFiles uses for disk paths.
+func (bp *BatchProcessor) processCorpusDocuments(ctx context.Context, docs []corpusDocument) []BatchResult {
+	docChan := make(chan corpusDocument, len(docs))
+	resultChan := make(chan BatchResult, len(docs))
+
+	var wg sync.WaitGroup
+	for i := 0; i < bp.options.MaxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for doc := range docChan {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				bp.metrics.incWorkers()
+				result := bp.processDocument(doc.source, doc.content)
+				bp.metrics.decWorkers()
+
+				select {
+				case resultChan <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(docChan)
+		for _, doc := range docs {
+			select {
+			case docChan <- doc:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var results []BatchResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,366 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiter paces HTTP fetches to at most one every interval, shared
+// across every worker so RateLimitPerSec bounds the whole crawl rather
+// than each worker individually. A nil *rateLimiter (RateLimitPerSec <= 0)
+// makes wait a no-op.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(perSec float64) *rateLimiter {
+	if perSec <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSec)}
+}
+
+// wait blocks until it's this caller's turn, or ctx is canceled.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	start := r.next
+	if start.Before(now) {
+		start = now
+	}
+	r.next = start.Add(r.interval)
+	r.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// urlCacheEntry records the conditional-GET validators and resulting
+// content hash for one fetched URL, so a 304 response can reuse the
+// matching batchCache entry without re-downloading the page.
+type urlCacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	ContentHash  string `json:"content_hash,omitempty"`
+}
+
+// urlCache is a URL -> urlCacheEntry map persisted as
+// CacheDir/http_cache.json, separate from batchCache's content-hash keyed
+// cache.json since a URL can outlive the page content it last pointed to.
+type urlCache struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]urlCacheEntry
+	dirty   bool
+}
+
+func loadURLCache(dir string) *urlCache {
+	c := &urlCache{dir: dir, entries: make(map[string]urlCacheEntry)}
+	if dir == "" {
+		return c
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "http_cache.json"))
+	if err != nil {
+		return c
+	}
+	_ = json.Unmarshal(data, &c.entries)
+	return c
+}
+
+func (c *urlCache) get(rawURL string) (urlCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[rawURL]
+	return entry, ok
+}
+
+func (c *urlCache) set(rawURL string, entry urlCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[rawURL] = entry
+	c.dirty = true
+}
+
+func (c *urlCache) save() error {
+	if c.dir == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, "http_cache.json"), data, 0644); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// robotsRules is a minimal robots.txt model: the Disallow paths listed
+// under a User-agent: * group, which is all Novofon's docs site needs.
+type robotsRules struct {
+	disallow []string
+}
+
+func (r *robotsRules) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	for _, prefix := range r.disallow {
+		if prefix != "" && strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// fetchRobots downloads and parses robots.txt for rawURL's host. A failed
+// fetch is treated as "no rules" rather than an error, since a missing or
+// unreachable robots.txt doesn't mean crawling is disallowed.
+func fetchRobots(ctx context.Context, client *http.Client, userAgent string, rawURL string) (*robotsRules, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", parsed.Scheme, parsed.Host)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &robotsRules{}, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &robotsRules{}, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &robotsRules{}, nil
+	}
+
+	return parseRobots(string(body)), nil
+}
+
+// parseRobots reads the Disallow lines of every User-agent: * group and of
+// any lines before the first User-agent line; Novofon's docs site has no
+// per-bot rules, so finer group matching isn't needed.
+func parseRobots(body string) *robotsRules {
+	rules := &robotsRules{}
+	applies := true
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		field := strings.ToLower(strings.TrimSpace(parts[0]))
+		value := strings.TrimSpace(parts[1])
+
+		switch field {
+		case "user-agent":
+			applies = value == "*"
+		case "disallow":
+			if applies && value != "" {
+				rules.disallow = append(rules.disallow, value)
+			}
+		}
+	}
+	return rules
+}
+
+// fetchDocument performs a rate-limited, retrying, conditional GET for
+// rawURL, honoring BatchOptions.RespectRobotsTXT, and reports whether the
+// response was a 304 Not Modified against bp.urlCache's stored validators.
+func (bp *BatchProcessor) fetchDocument(ctx context.Context, rawURL string) (content []byte, notModified bool, err error) {
+	if bp.options.RespectRobotsTXT {
+		rules, err := bp.robotsFor(ctx, rawURL)
+		if err != nil {
+			return nil, false, fmt.Errorf("fetch robots.txt: %w", err)
+		}
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, false, err
+		}
+		if !rules.allowed(parsed.Path) {
+			return nil, false, fmt.Errorf("disallowed by robots.txt: %s", rawURL)
+		}
+	}
+
+	entry, hadEntry := bp.urlCache.get(rawURL)
+
+	attempts := bp.options.RetryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			}
+		}
+
+		if err := bp.rateLimiter.wait(ctx); err != nil {
+			return nil, false, err
+		}
+
+		body, notMod, err := bp.doGet(ctx, rawURL, entry, hadEntry)
+		if err == nil {
+			return body, notMod, nil
+		}
+		lastErr = err
+	}
+
+	return nil, false, lastErr
+}
+
+func (bp *BatchProcessor) doGet(ctx context.Context, rawURL string, entry urlCacheEntry, hadEntry bool) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("build request: %w", err)
+	}
+	if bp.options.UserAgent != "" {
+		req.Header.Set("User-Agent", bp.options.UserAgent)
+	}
+	if hadEntry {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := bp.httpClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status %d for %s", resp.StatusCode, rawURL)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("read body: %w", err)
+	}
+
+	bp.urlCache.set(rawURL, urlCacheEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentHash:  cacheKey(body),
+	})
+
+	return body, false, nil
+}
+
+func (bp *BatchProcessor) robotsFor(ctx context.Context, rawURL string) (*robotsRules, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	bp.robotsMu.Lock()
+	defer bp.robotsMu.Unlock()
+	if bp.robotsCache == nil {
+		bp.robotsCache = make(map[string]*robotsRules)
+	}
+	if rules, ok := bp.robotsCache[parsed.Host]; ok {
+		return rules, nil
+	}
+
+	rules, err := fetchRobots(ctx, bp.httpClient, bp.options.UserAgent, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	bp.robotsCache[parsed.Host] = rules
+	return rules, nil
+}
+
+// sitemapURLSet is the subset of the sitemap XML schema ProcessSitemap
+// needs: a flat list of page URLs.
+type sitemapURLSet struct {
+	XMLName xml.Name       `xml:"urlset"`
+	URLs    []sitemapEntry `xml:"url"`
+}
+
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// fetchSitemapURLs downloads sitemapURL and returns every <loc> it lists.
+func (bp *BatchProcessor) fetchSitemapURLs(ctx context.Context, sitemapURL string) ([]string, error) {
+	body, _, err := bp.fetchDocument(ctx, sitemapURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sitemap: %w", err)
+	}
+
+	var set sitemapURLSet
+	if err := xml.Unmarshal(body, &set); err != nil {
+		return nil, fmt.Errorf("parse sitemap: %w", err)
+	}
+
+	urls := make([]string, 0, len(set.URLs))
+	for _, entry := range set.URLs {
+		if entry.Loc != "" {
+			urls = append(urls, entry.Loc)
+		}
+	}
+	return urls, nil
+}
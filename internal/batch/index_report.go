@@ -0,0 +1,248 @@
+package batch
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// indexReportTemplate renders a BatchReport as a browsable index.html:
+// methods grouped by namespace prefix (the part of the method name before
+// the first '.'), with sortable columns and a search box implemented in
+// plain JS — no framework needed for a table this size.
+var indexReportTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Novofon API Index</title>
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2rem; color: #1a1a1a; }
+h1, h2 { margin-top: 2rem; }
+table { border-collapse: collapse; width: 100%; margin-top: 0.5rem; }
+th, td { border: 1px solid #ddd; padding: 0.35rem 0.6rem; text-align: left; font-size: 0.9rem; }
+th { background: #f5f5f5; cursor: pointer; user-select: none; }
+th.sortable:hover { background: #eaeaea; }
+.ok { color: #0a7a0a; }
+.fail { color: #b00020; }
+.summary { display: flex; gap: 2rem; flex-wrap: wrap; }
+.summary div { background: #f5f5f5; padding: 0.75rem 1rem; border-radius: 4px; }
+code { background: #f0f0f0; padding: 0 0.25rem; }
+#search { width: 100%; max-width: 24rem; padding: 0.4rem 0.6rem; margin: 1rem 0; font-size: 0.95rem; }
+tr.hidden { display: none; }
+</style>
+</head>
+<body>
+<h1>Novofon API Index</h1>
+<div class="summary">
+<div>Methods<br><strong>{{.TotalMethods}}</strong></div>
+<div>Success<br><strong>{{.Report.SuccessCount}}</strong></div>
+<div>Errors<br><strong>{{.Report.ErrorCount}}</strong></div>
+<div>Total size<br><strong>{{.TotalSize}}</strong></div>
+<div>Total time<br><strong>{{.Report.TotalTime}}</strong></div>
+</div>
+
+<input type="text" id="search" placeholder="Filter by method name...">
+
+{{range .Namespaces}}
+<h2>{{.Prefix}}.*</h2>
+<table class="sortable">
+<tr>
+<th data-key="name">Name</th>
+<th>Status</th>
+<th data-key="params">Params</th>
+<th data-key="errors">Errors</th>
+<th data-key="duration">Duration</th>
+<th data-key="size">Size</th>
+<th>Artifacts</th>
+</tr>
+{{range .Methods}}<tr class="row" data-name="{{.Name}}" data-params="{{.Params}}" data-errors="{{.Errors}}" data-duration="{{.DurationMS}}" data-size="{{.SizeBytes}}">
+<td><code>{{.Name}}</code></td>
+<td class="{{if .Success}}ok{{else}}fail{{end}}">{{if .Success}}ok{{else}}error{{end}}</td>
+<td>{{.Params}}</td>
+<td>{{.Errors}}</td>
+<td>{{.Duration}}</td>
+<td>{{.HumanSize}}</td>
+<td>{{range .Links}}<a href="{{.}}">{{.}}</a> {{end}}</td>
+</tr>
+{{end}}</table>
+{{end}}
+
+<script>
+document.getElementById('search').addEventListener('input', function(e) {
+  var q = e.target.value.toLowerCase();
+  document.querySelectorAll('tr.row').forEach(function(row) {
+    var name = row.getAttribute('data-name').toLowerCase();
+    row.classList.toggle('hidden', q !== '' && name.indexOf(q) === -1);
+  });
+});
+
+document.querySelectorAll('table.sortable').forEach(function(table) {
+  table.querySelectorAll('th[data-key]').forEach(function(th, headerIndex) {
+    var key = th.getAttribute('data-key');
+    var ascending = true;
+    th.addEventListener('click', function() {
+      var tbody = table;
+      var rows = Array.prototype.slice.call(table.querySelectorAll('tr.row'));
+      rows.sort(function(a, b) {
+        var av = key === 'name' ? a.getAttribute('data-name') : parseFloat(a.getAttribute('data-' + key));
+        var bv = key === 'name' ? b.getAttribute('data-name') : parseFloat(b.getAttribute('data-' + key));
+        if (av < bv) return ascending ? -1 : 1;
+        if (av > bv) return ascending ? 1 : -1;
+        return 0;
+      });
+      rows.forEach(function(row) { table.appendChild(row); });
+      ascending = !ascending;
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+// indexMethod is one method's row in the index: its status, counts, and
+// links to whatever saveFileOutput wrote for it.
+type indexMethod struct {
+	Name       string
+	Success    bool
+	Params     int
+	Errors     int
+	Duration   time.Duration
+	DurationMS int64
+	SizeBytes  int64
+	HumanSize  string
+	Links      []string
+}
+
+// indexNamespace groups every method sharing a name prefix (the part
+// before the first '.', e.g. "start" for "start.simple_call").
+type indexNamespace struct {
+	Prefix  string
+	Methods []indexMethod
+}
+
+// indexReportData is the top-level value passed to indexReportTemplate.
+type indexReportData struct {
+	Report       *BatchReport
+	Namespaces   []indexNamespace
+	TotalMethods int
+	TotalSize    string
+}
+
+// renderIndexReport builds index.html's contents for report.
+func (bp *BatchProcessor) renderIndexReport(report *BatchReport) ([]byte, error) {
+	namespaces, totalMethods, totalSize := bp.indexNamespaces(report)
+
+	data := indexReportData{
+		Report:       report,
+		Namespaces:   namespaces,
+		TotalMethods: totalMethods,
+		TotalSize:    humanBytes(totalSize),
+	}
+
+	var buf bytes.Buffer
+	if err := indexReportTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// indexNamespaces groups report's successfully parsed methods by namespace
+// prefix, sorted alphabetically by prefix and by method name within each
+// group, and returns the overall method count and total artifact size
+// alongside them.
+func (bp *BatchProcessor) indexNamespaces(report *BatchReport) ([]indexNamespace, int, int64) {
+	formats := splitFormats(bp.options.Format)
+	groups := make(map[string][]indexMethod)
+	var totalMethods int
+	var totalSize int64
+
+	for _, result := range report.Results {
+		if result.APIData == nil || result.APIData.MethodInfo == nil {
+			continue
+		}
+		name := result.APIData.MethodInfo.Name
+		safeName := strings.ReplaceAll(name, ".", "_")
+
+		var params, errorCount int
+		if result.APIData.RequestParams != nil {
+			params += len(result.APIData.RequestParams)
+		}
+		if result.APIData.ResponseParams != nil {
+			params += len(result.APIData.ResponseParams)
+		}
+		if result.APIData.ErrorInfo != nil {
+			errorCount = len(result.APIData.ErrorInfo.Errors)
+		}
+
+		var links []string
+		var size int64
+		for _, formatName := range formats {
+			writer, ok := bp.writers[formatName]
+			if !ok {
+				continue
+			}
+			link := safeName + "." + writer.Extension()
+			links = append(links, link)
+			if bp.options.OutputDir != "" {
+				if info, err := os.Stat(filepath.Join(bp.options.OutputDir, link)); err == nil {
+					size += info.Size()
+				}
+			}
+		}
+
+		method := indexMethod{
+			Name:       name,
+			Success:    result.Success,
+			Params:     params,
+			Errors:     errorCount,
+			Duration:   result.ProcessTime,
+			DurationMS: result.ProcessTime.Milliseconds(),
+			SizeBytes:  size,
+			HumanSize:  humanBytes(size),
+			Links:      links,
+		}
+
+		prefix := namespacePrefix(name)
+		groups[prefix] = append(groups[prefix], method)
+		totalMethods++
+		totalSize += size
+	}
+
+	var namespaces []indexNamespace
+	for prefix, methods := range groups {
+		sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+		namespaces = append(namespaces, indexNamespace{Prefix: prefix, Methods: methods})
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Prefix < namespaces[j].Prefix })
+
+	return namespaces, totalMethods, totalSize
+}
+
+// namespacePrefix returns methodName's namespace: the part before its
+// first '.', or the whole name if it has none.
+func namespacePrefix(methodName string) string {
+	if i := strings.Index(methodName, "."); i >= 0 {
+		return methodName[:i]
+	}
+	return methodName
+}
+
+// humanBytes formats n as a human-readable size (bytes, KB, MB, ...).
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
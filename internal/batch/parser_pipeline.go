@@ -0,0 +1,244 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xcono/novofon/internal/models"
+	"github.com/xcono/novofon/internal/parse"
+)
+
+// ErrorPolicy controls how BatchParser.Parse reacts to a per-file parse
+// error.
+type ErrorPolicy int
+
+const (
+	// AbortOnError stops dispatching new files to workers as soon as any
+	// file fails, though files already in flight still finish.
+	AbortOnError ErrorPolicy = iota
+	// SkipOnError logs nothing special and simply moves on to the next
+	// file; the failing Result still arrives on the results channel.
+	SkipOnError
+	// CollectErrors behaves like SkipOnError but callers are expected to
+	// gather every failing Result themselves; it exists as a distinct
+	// value so call sites can express their intent even though the
+	// pipeline's own behavior doesn't otherwise differ from SkipOnError.
+	CollectErrors
+)
+
+// SelectFunc decides whether path should be parsed, analogous to restic's
+// SelectFilter. A nil SelectFunc selects every path.
+type SelectFunc func(path string, info fs.FileInfo) bool
+
+// Result is one file's outcome from a BatchParser run.
+type Result struct {
+	Path        string
+	APIData     *models.APIData
+	Diagnostics []parse.Diagnostic
+	Err         error
+	Duration    time.Duration
+}
+
+// Progress is a snapshot of a BatchParser run's overall state, emitted
+// after each file finishes.
+type Progress struct {
+	Done      int
+	Total     int
+	Failed    int
+	BytesRead int64
+}
+
+// BatchParserOptions configures a BatchParser.
+type BatchParserOptions struct {
+	// WorkerCount bounds how many files are parsed concurrently. 0
+	// defaults to runtime.NumCPU().
+	WorkerCount int
+	// ErrorPolicy controls how a failing file affects the rest of the run.
+	ErrorPolicy ErrorPolicy
+	// Select, if set, filters which paths are parsed at all.
+	Select SelectFunc
+	// Validate runs a parse.Validator over each successfully parsed
+	// APIData. A Result with any SeverityError Diagnostic gets its Err
+	// set to a summarizing error, so ErrorPolicy's AbortOnError/failed
+	// accounting applies to validation failures the same way it does to
+	// parse failures.
+	Validate bool
+}
+
+// BatchParser drives parse.Parser.ParseHTML across a bounded worker pool,
+// consuming a DirectoryScanner's file list and emitting per-file Results
+// and aggregate Progress snapshots as it goes.
+type BatchParser struct {
+	options BatchParserOptions
+}
+
+// NewBatchParser creates a BatchParser with the given options.
+func NewBatchParser(options BatchParserOptions) *BatchParser {
+	if options.WorkerCount <= 0 {
+		if n := runtime.NumCPU(); n > 0 {
+			options.WorkerCount = n
+		} else {
+			options.WorkerCount = 1
+		}
+	}
+	return &BatchParser{options: options}
+}
+
+// Parse parses every path in paths across the configured worker pool,
+// returning a channel of per-file Results and a channel of Progress
+// snapshots (one per finished file). Both channels are closed once the
+// run completes, whether that's because every file finished, ctx was
+// canceled, or ErrorPolicy is AbortOnError and a file failed. Callers
+// should drain both channels to avoid leaking worker goroutines.
+func (bpar *BatchParser) Parse(ctx context.Context, paths []string) (<-chan Result, <-chan Progress) {
+	selected := paths
+	if bpar.options.Select != nil {
+		selected = make([]string, 0, len(paths))
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil || !bpar.options.Select(path, info) {
+				continue
+			}
+			selected = append(selected, path)
+		}
+	}
+
+	pathChan := make(chan string, bpar.options.WorkerCount)
+	resultChan := make(chan Result, bpar.options.WorkerCount)
+	progressChan := make(chan Progress, bpar.options.WorkerCount)
+
+	var done, failed int64
+	var bytesRead int64
+	var aborted int32
+	total := len(selected)
+
+	var workers sync.WaitGroup
+	for i := 0; i < bpar.options.WorkerCount; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			parser := parse.NewParser()
+			for path := range pathChan {
+				if atomic.LoadInt32(&aborted) != 0 {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					continue
+				default:
+				}
+
+				result, size := bpar.parseFile(parser, path)
+
+				atomic.AddInt64(&done, 1)
+				atomic.AddInt64(&bytesRead, size)
+				if result.Err != nil {
+					atomic.AddInt64(&failed, 1)
+					if bpar.options.ErrorPolicy == AbortOnError {
+						atomic.StoreInt32(&aborted, 1)
+					}
+				}
+
+				select {
+				case resultChan <- result:
+				case <-ctx.Done():
+					continue
+				}
+
+				select {
+				case progressChan <- Progress{
+					Done:      int(atomic.LoadInt64(&done)),
+					Total:     total,
+					Failed:    int(atomic.LoadInt64(&failed)),
+					BytesRead: atomic.LoadInt64(&bytesRead),
+				}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pathChan)
+		for _, path := range selected {
+			if atomic.LoadInt32(&aborted) != 0 {
+				return
+			}
+			select {
+			case pathChan <- path:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		workers.Wait()
+		close(resultChan)
+		close(progressChan)
+	}()
+
+	return resultChan, progressChan
+}
+
+// parseFile reads and parses one file, returning its Result alongside the
+// number of bytes read (for Progress.BytesRead).
+func (bpar *BatchParser) parseFile(parser *parse.Parser, path string) (Result, int64) {
+	startTime := time.Now()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Result{Path: path, Err: err, Duration: time.Since(startTime)}, 0
+	}
+
+	apiData, err := parser.ParseHTML(string(content))
+	result := Result{
+		Path:     path,
+		APIData:  apiData,
+		Err:      err,
+		Duration: time.Since(startTime),
+	}
+
+	if err == nil && bpar.options.Validate {
+		diags := parser.Validate(apiData)
+		failed := 0
+		for i := range diags {
+			diags[i].File = path
+			if diags[i].Severity == parse.SeverityError {
+				failed++
+			}
+		}
+		result.Diagnostics = diags
+		if failed > 0 {
+			result.Err = fmt.Errorf("%d validation error(s): %w", failed, errDiagnostics(diags))
+		}
+	}
+
+	return result, int64(len(content))
+}
+
+// errDiagnostics is a sentinel-free error wrapping the SeverityError
+// diagnostics from a failed validation pass, so Result.Err carries a
+// human-readable summary without callers needing to separately inspect
+// Result.Diagnostics just to learn why a file failed.
+type errDiagnostics []parse.Diagnostic
+
+func (e errDiagnostics) Error() string {
+	msg := ""
+	for _, d := range e {
+		if d.Severity != parse.SeverityError {
+			continue
+		}
+		if msg != "" {
+			msg += "; "
+		}
+		msg += d.Code + ": " + d.Message
+	}
+	return msg
+}
@@ -0,0 +1,112 @@
+package batch
+
+import (
+	"encoding/json"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+// postmanCollectionSchema is the Postman v2.1 collection schema URI every
+// collection's info block must declare.
+const postmanCollectionSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// postmanCollection is the minimal Postman v2.1 collection shape needed
+// to import a JSON-RPC method as a request.
+type postmanCollection struct {
+	Info postmanInfo   `json:"info"`
+	Item []postmanItem `json:"item"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanItem struct {
+	Name    string         `json:"name"`
+	Request postmanRequest `json:"request"`
+}
+
+type postmanRequest struct {
+	Method string          `json:"method"`
+	Header []postmanHeader `json:"header"`
+	Body   postmanBody     `json:"body"`
+	URL    postmanURL      `json:"url"`
+}
+
+type postmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanBody struct {
+	Mode string `json:"mode"`
+	Raw  string `json:"raw"`
+}
+
+type postmanURL struct {
+	Raw  string   `json:"raw"`
+	Host []string `json:"host"`
+}
+
+// newPostmanCollection builds a single-request Postman v2.1 collection
+// for apiData's method, using its parsed JSON-RPC request example as the
+// request body when the docs included one.
+func newPostmanCollection(apiData *models.APIData) postmanCollection {
+	name := "unknown"
+	if apiData != nil && apiData.MethodInfo != nil {
+		name = apiData.MethodInfo.Name
+	}
+
+	body := postmanRequestBody(apiData)
+
+	return postmanCollection{
+		Info: postmanInfo{
+			Name:   name,
+			Schema: postmanCollectionSchema,
+		},
+		Item: []postmanItem{
+			{
+				Name: name,
+				Request: postmanRequest{
+					Method: "POST",
+					Header: []postmanHeader{
+						{Key: "Content-Type", Value: "application/json"},
+					},
+					Body: postmanBody{Mode: "raw", Raw: body},
+					URL: postmanURL{
+						Raw:  "{{base_url}}",
+						Host: []string{"{{base_url}}"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// postmanRequestBody prefers the JSON-RPC example the docs themselves
+// show (apiData.RequestJSON), falling back to a minimal envelope with an
+// empty params object when no example was parsed.
+func postmanRequestBody(apiData *models.APIData) string {
+	if apiData == nil || apiData.MethodInfo == nil {
+		return "{}"
+	}
+
+	if len(apiData.RequestJSON) > 0 {
+		if data, err := json.MarshalIndent(apiData.RequestJSON, "", "  "); err == nil {
+			return string(data)
+		}
+	}
+
+	envelope := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  apiData.MethodInfo.Name,
+		"params":  map[string]interface{}{},
+	}
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
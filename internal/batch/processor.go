@@ -0,0 +1,1065 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/xcono/novofon/internal/generate"
+	"github.com/xcono/novofon/internal/models"
+	"github.com/xcono/novofon/internal/parse"
+	"github.com/xcono/novofon/internal/validate"
+)
+
+// BatchProcessor handles processing multiple HTML files
+type BatchProcessor struct {
+	parser    *parse.Parser
+	generator *generate.OpenAPIGenerator
+	validator *validate.SchemaValidator
+	options   *BatchOptions
+	metrics   *batchMetrics
+	logger    *slog.Logger
+	cache     *batchCache
+	writers   map[string]OutputWriter
+
+	// httpClient, rateLimiter, and urlCache back ProcessURLs/ProcessSitemap;
+	// they're unused by the disk-based ProcessDirectory/ProcessFiles paths.
+	httpClient  *http.Client
+	rateLimiter *rateLimiter
+	urlCache    *urlCache
+
+	robotsMu    sync.Mutex
+	robotsCache map[string]*robotsRules
+}
+
+// BatchOptions configures batch processing behavior
+type BatchOptions struct {
+	MaxWorkers int    // Maximum number of concurrent workers
+	OutputDir  string // Output directory for results
+	// Format is a comma-separated list of registered OutputWriter names
+	// (e.g. "json,postman"), so one pass can emit several artifacts per
+	// file. Defaults to "json" when empty. Built in: json, yaml, openapi,
+	// openapi31, jsonschema, postman.
+	Format          string
+	Validate        bool          // Enable validation
+	GenerateOpenAPI bool          // Generate OpenAPI specs
+	GenerateReport  bool          // Generate processing report
+	SkipErrors      bool          // Skip files with errors
+	Verbose         bool          // Enable verbose output
+	Timeout         time.Duration // Processing timeout per file
+	// MetricsRegistry, if set, turns on Prometheus instrumentation
+	// (novofon_batch_files_total, novofon_batch_file_duration_seconds,
+	// novofon_batch_parse_duration_seconds, novofon_batch_validate_duration_seconds,
+	// novofon_batch_workers_in_flight). Nil disables metrics entirely.
+	MetricsRegistry *prometheus.Registry
+	// Logger receives structured per-file events (parse_start, parse_ok,
+	// parse_fail, validate_fail). Defaults to slog.Default().
+	Logger *slog.Logger
+	// CacheDir, if set, enables a SHA-256(content)-keyed cache.json under
+	// this directory, so re-running a batch over mostly-unchanged HTML
+	// can skip re-parsing and re-validating files whose content hasn't
+	// changed.
+	CacheDir string
+	// IgnoreCache forces every file to be reprocessed even when CacheDir
+	// has a matching cached result. Equivalent to a --force flag layered
+	// on top of CacheDir.
+	IgnoreCache bool
+	// PreserveOrder makes generated schemas emit properties in the order
+	// the parser discovered them in HTML, rather than alphabetical order.
+	// NewBatchProcessor backfills the zero value the same way it does
+	// MaxWorkers/Timeout, so constructing BatchOptions{} still gets
+	// deterministic HTML-order output; since false is indistinguishable
+	// from unset, it is always true after NewBatchProcessor and
+	// alphabetical order cannot be selected through this field.
+	PreserveOrder bool
+
+	// The fields below configure ProcessURLs/ProcessSitemap, the live
+	// HTTP crawler mode. They have no effect on ProcessDirectory/
+	// ProcessFiles, which only ever read from disk.
+
+	// HTTPClient is used for every fetch. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// RateLimitPerSec caps fetches across all workers combined to at most
+	// this many per second. Zero (the default) disables rate limiting.
+	RateLimitPerSec float64
+	// RetryAttempts is the total number of tries per fetch, the first one
+	// included, with a linear backoff of attempt*1s between tries.
+	// Defaults to 1 (no retry).
+	RetryAttempts int
+	// RespectRobotsTXT, when true, fetches and honors each host's
+	// robots.txt before crawling any of its pages.
+	RespectRobotsTXT bool
+	// UserAgent is sent on every request. Defaults to the Go http package's
+	// own default when empty.
+	UserAgent string
+
+	// HTMLReport, when true, makes saveReport additionally render a
+	// browsable <OutputDir>/index.html: every processed method grouped by
+	// namespace prefix, with sortable columns and a search box, linking
+	// to each method's generated output artifacts.
+	HTMLReport bool
+}
+
+// batchMetrics holds the Prometheus instruments NewBatchProcessor registers
+// against BatchOptions.MetricsRegistry. A nil *batchMetrics (no registry
+// configured) makes every method a no-op, so call sites never need to
+// check whether metrics are enabled.
+type batchMetrics struct {
+	filesTotal       *prometheus.CounterVec
+	fileDuration     prometheus.Histogram
+	parseDuration    prometheus.Histogram
+	validateDuration prometheus.Histogram
+	workersInFlight  prometheus.Gauge
+}
+
+// newBatchMetrics registers batch instruments against reg, or returns nil
+// if reg is nil.
+func newBatchMetrics(reg *prometheus.Registry) *batchMetrics {
+	if reg == nil {
+		return nil
+	}
+
+	factory := promauto.With(reg)
+	return &batchMetrics{
+		filesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "novofon_batch_files_total",
+			Help: "Total HTML files processed by BatchProcessor, by outcome.",
+		}, []string{"status"}),
+		fileDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Name: "novofon_batch_file_duration_seconds",
+			Help: "Time to fully process one HTML file.",
+		}),
+		parseDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Name: "novofon_batch_parse_duration_seconds",
+			Help: "Time spent parsing one HTML file.",
+		}),
+		validateDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Name: "novofon_batch_validate_duration_seconds",
+			Help: "Time spent validating one file's extracted API data.",
+		}),
+		workersInFlight: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "novofon_batch_workers_in_flight",
+			Help: "Number of batch workers currently processing a file.",
+		}),
+	}
+}
+
+func (m *batchMetrics) incWorkers() {
+	if m == nil {
+		return
+	}
+	m.workersInFlight.Inc()
+}
+
+func (m *batchMetrics) decWorkers() {
+	if m == nil {
+		return
+	}
+	m.workersInFlight.Dec()
+}
+
+func (m *batchMetrics) observeFile(status string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.filesTotal.WithLabelValues(status).Inc()
+	m.fileDuration.Observe(d.Seconds())
+}
+
+func (m *batchMetrics) observeParse(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.parseDuration.Observe(d.Seconds())
+}
+
+func (m *batchMetrics) observeValidate(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.validateDuration.Observe(d.Seconds())
+}
+
+// ServeMetrics starts a blocking HTTP server exposing the batch's
+// Prometheus metrics at /metrics on addr, so a long directory scan can be
+// watched live. Returns an error immediately if no MetricsRegistry was
+// configured.
+func (bp *BatchProcessor) ServeMetrics(addr string) error {
+	if bp.options.MetricsRegistry == nil {
+		return fmt.Errorf("ServeMetrics requires BatchOptions.MetricsRegistry to be set")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(bp.options.MetricsRegistry, promhttp.HandlerOpts{}))
+	return http.ListenAndServe(addr, mux)
+}
+
+// BatchResult represents the result of processing a single file
+type BatchResult struct {
+	FilePath string `json:"file_path"`
+	// Source records where this result's HTML came from: a disk path for
+	// ProcessDirectory/ProcessFiles, or the fetched URL for
+	// ProcessURLs/ProcessSitemap. FilePath is set to the same value so
+	// existing output/report code keyed on FilePath keeps working
+	// unchanged for both sources.
+	Source      string                     `json:"source,omitempty"`
+	Success     bool                       `json:"success"`
+	Error       string                     `json:"error,omitempty"`
+	APIData     *models.APIData            `json:"api_data,omitempty"`
+	OpenAPISpec *generate.OpenAPISpec      `json:"openapi_spec,omitempty"`
+	Validation  *validate.ValidationResult `json:"validation,omitempty"`
+	ProcessTime time.Duration              `json:"process_time"`
+	// CacheHit reports whether this result was loaded from CacheDir
+	// instead of re-parsed.
+	CacheHit bool `json:"cache_hit,omitempty"`
+}
+
+// BatchReport represents the overall batch processing report
+type BatchReport struct {
+	StartTime    time.Time     `json:"start_time"`
+	EndTime      time.Time     `json:"end_time"`
+	TotalFiles   int           `json:"total_files"`
+	SuccessCount int           `json:"success_count"`
+	ErrorCount   int           `json:"error_count"`
+	SkippedCount int           `json:"skipped_count"`
+	TotalTime    time.Duration `json:"total_time"`
+	Results      []BatchResult `json:"results"`
+	Summary      BatchSummary  `json:"summary"`
+}
+
+// BatchSummary provides summary statistics
+type BatchSummary struct {
+	APIMethods      []string `json:"api_methods"`
+	ErrorTypes      []string `json:"error_types"`
+	AverageTime     float64  `json:"average_time_ms"`
+	FastestFile     string   `json:"fastest_file"`
+	SlowestFile     string   `json:"slowest_file"`
+	TotalParams     int      `json:"total_parameters"`
+	TotalErrors     int      `json:"total_errors"`
+	CacheHits       int      `json:"cache_hits"`
+	CacheMisses     int      `json:"cache_misses"`
+	CacheBytesSaved int64    `json:"cache_bytes_saved"`
+}
+
+// NewBatchProcessor creates a new batch processor
+func NewBatchProcessor(options *BatchOptions) *BatchProcessor {
+	if options.MaxWorkers <= 0 {
+		options.MaxWorkers = 4 // Default to 4 workers
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 30 * time.Second // Default 30 second timeout
+	}
+	if !options.PreserveOrder {
+		options.PreserveOrder = true // Default to preserving HTML discovery order
+	}
+
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	cache, err := loadBatchCache(options.CacheDir)
+	if err != nil {
+		logger.Warn("batch cache load failed, continuing without it", "dir", options.CacheDir, "error", err)
+		cache, _ = loadBatchCache("")
+	}
+
+	validator := validate.NewSchemaValidator()
+	validator.SetPreserveOrder(options.PreserveOrder)
+
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	bp := &BatchProcessor{
+		parser:      parse.NewParser(),
+		generator:   generate.NewOpenAPIGenerator(),
+		validator:   validator,
+		options:     options,
+		metrics:     newBatchMetrics(options.MetricsRegistry),
+		logger:      logger,
+		cache:       cache,
+		writers:     make(map[string]OutputWriter),
+		httpClient:  httpClient,
+		rateLimiter: newRateLimiter(options.RateLimitPerSec),
+		urlCache:    loadURLCache(options.CacheDir),
+	}
+	bp.registerBuiltinWriters()
+	return bp
+}
+
+// ProcessDirectory processes all HTML files in a directory
+func (bp *BatchProcessor) ProcessDirectory(ctx context.Context, dirPath string) (*BatchReport, error) {
+	startTime := time.Now()
+
+	// Find all HTML files
+	htmlFiles, err := bp.findHTMLFiles(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find HTML files: %w", err)
+	}
+
+	if len(htmlFiles) == 0 {
+		return &BatchReport{
+			StartTime:    startTime,
+			EndTime:      time.Now(),
+			TotalFiles:   0,
+			SuccessCount: 0,
+			ErrorCount:   0,
+			TotalTime:    time.Since(startTime),
+			Results:      []BatchResult{},
+			Summary:      BatchSummary{},
+		}, nil
+	}
+
+	if bp.options.Verbose {
+		bp.logger.Info("found html files", "count", len(htmlFiles))
+	}
+
+	// Create output directory if needed
+	if bp.options.OutputDir != "" {
+		if err := os.MkdirAll(bp.options.OutputDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	// Process files
+	results := bp.processFiles(ctx, htmlFiles)
+
+	// Generate report
+	report := bp.generateReport(startTime, results)
+
+	// Save report if requested
+	if bp.options.GenerateReport {
+		if err := bp.saveReport(report); err != nil {
+			return nil, fmt.Errorf("failed to save report: %w", err)
+		}
+	}
+
+	if err := bp.cache.save(); err != nil {
+		bp.logger.Warn("batch cache save failed", "dir", bp.options.CacheDir, "error", err)
+	}
+
+	return report, nil
+}
+
+// ProcessFiles processes a list of specific files
+func (bp *BatchProcessor) ProcessFiles(ctx context.Context, filePaths []string) (*BatchReport, error) {
+	startTime := time.Now()
+
+	if len(filePaths) == 0 {
+		return &BatchReport{
+			StartTime:    startTime,
+			EndTime:      time.Now(),
+			TotalFiles:   0,
+			SuccessCount: 0,
+			ErrorCount:   0,
+			TotalTime:    time.Since(startTime),
+			Results:      []BatchResult{},
+			Summary:      BatchSummary{},
+		}, nil
+	}
+
+	if bp.options.Verbose {
+		bp.logger.Info("processing files", "count", len(filePaths))
+	}
+
+	// Create output directory if needed
+	if bp.options.OutputDir != "" {
+		if err := os.MkdirAll(bp.options.OutputDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	// Process files
+	results := bp.processFiles(ctx, filePaths)
+
+	// Generate report
+	report := bp.generateReport(startTime, results)
+
+	// Save report if requested
+	if bp.options.GenerateReport {
+		if err := bp.saveReport(report); err != nil {
+			return nil, fmt.Errorf("failed to save report: %w", err)
+		}
+	}
+
+	if err := bp.cache.save(); err != nil {
+		bp.logger.Warn("batch cache save failed", "dir", bp.options.CacheDir, "error", err)
+	}
+
+	return report, nil
+}
+
+// ProcessURLs fetches each URL directly from a live Novofon docs site and
+// runs it through the same parse/generate/validate/save pipeline
+// ProcessDirectory and ProcessFiles use, so the tool can regenerate specs
+// without a pre-downloaded HTML tree. Concurrency is bounded by both
+// MaxWorkers and RateLimitPerSec; a page whose ETag/Last-Modified still
+// match CacheDir's stored validators is skipped (its prior cached result
+// is reused) instead of being re-downloaded.
+func (bp *BatchProcessor) ProcessURLs(ctx context.Context, urls []string) (*BatchReport, error) {
+	startTime := time.Now()
+
+	if len(urls) == 0 {
+		return &BatchReport{
+			StartTime: startTime,
+			EndTime:   time.Now(),
+			TotalTime: time.Since(startTime),
+			Results:   []BatchResult{},
+			Summary:   BatchSummary{},
+		}, nil
+	}
+
+	if bp.options.Verbose {
+		bp.logger.Info("fetching urls", "count", len(urls))
+	}
+
+	if bp.options.OutputDir != "" {
+		if err := os.MkdirAll(bp.options.OutputDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	results := bp.fetchAndProcessURLs(ctx, urls)
+	report := bp.generateReport(startTime, results)
+
+	if bp.options.GenerateReport {
+		if err := bp.saveReport(report); err != nil {
+			return nil, fmt.Errorf("failed to save report: %w", err)
+		}
+	}
+
+	if err := bp.cache.save(); err != nil {
+		bp.logger.Warn("batch cache save failed", "dir", bp.options.CacheDir, "error", err)
+	}
+	if err := bp.urlCache.save(); err != nil {
+		bp.logger.Warn("url cache save failed", "dir", bp.options.CacheDir, "error", err)
+	}
+
+	return report, nil
+}
+
+// ProcessSitemap fetches sitemapURL, extracts every <loc> it lists, and
+// runs them through ProcessURLs.
+func (bp *BatchProcessor) ProcessSitemap(ctx context.Context, sitemapURL string) (*BatchReport, error) {
+	urls, err := bp.fetchSitemapURLs(ctx, sitemapURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sitemap: %w", err)
+	}
+	return bp.ProcessURLs(ctx, urls)
+}
+
+// fetchAndProcessURLs fetches urls concurrently (one worker goroutine per
+// MaxWorkers, each additionally paced by bp.rateLimiter) and runs each
+// fetched document through processDocument.
+func (bp *BatchProcessor) fetchAndProcessURLs(ctx context.Context, urls []string) []BatchResult {
+	urlChan := make(chan string, len(urls))
+	resultChan := make(chan BatchResult, len(urls))
+
+	var wg sync.WaitGroup
+	for i := 0; i < bp.options.MaxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rawURL := range urlChan {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				bp.metrics.incWorkers()
+				result := bp.fetchAndProcess(ctx, rawURL)
+				bp.metrics.decWorkers()
+
+				select {
+				case resultChan <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(urlChan)
+		for _, rawURL := range urls {
+			select {
+			case urlChan <- rawURL:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var results []BatchResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+	return results
+}
+
+// fetchAndProcess fetches rawURL (respecting robots.txt, the rate limiter,
+// and RetryAttempts) and feeds the response into processDocument. A 304
+// Not Modified response reuses the batchCache entry recorded the last
+// time this URL's content changed, rather than re-parsing anything.
+func (bp *BatchProcessor) fetchAndProcess(ctx context.Context, rawURL string) BatchResult {
+	startTime := time.Now()
+
+	body, notModified, err := bp.fetchDocument(ctx, rawURL)
+	if err != nil {
+		return BatchResult{
+			FilePath:    rawURL,
+			Source:      rawURL,
+			Error:       fmt.Sprintf("fetch failed: %v", err),
+			ProcessTime: time.Since(startTime),
+		}
+	}
+
+	if notModified {
+		entry, _ := bp.urlCache.get(rawURL)
+		if cached, ok := bp.cache.lookup(entry.ContentHash); ok {
+			return BatchResult{
+				FilePath:    rawURL,
+				Source:      rawURL,
+				Success:     true,
+				CacheHit:    true,
+				APIData:     cached.APIData,
+				OpenAPISpec: cached.OpenAPISpec,
+				Validation:  cached.Validation,
+				ProcessTime: time.Since(startTime),
+			}
+		}
+		// The page is unchanged but we have no matching cache entry
+		// (e.g. CacheDir was cleared); fall through to fetch with
+		// validators dropped so a real body comes back.
+		bp.urlCache.set(rawURL, urlCacheEntry{})
+		body, _, err = bp.fetchDocument(ctx, rawURL)
+		if err != nil {
+			return BatchResult{
+				FilePath:    rawURL,
+				Source:      rawURL,
+				Error:       fmt.Sprintf("fetch failed: %v", err),
+				ProcessTime: time.Since(startTime),
+			}
+		}
+	}
+
+	return bp.processDocument(rawURL, body)
+}
+
+// findHTMLFiles recursively finds all HTML files in a directory, including
+// transparently-compressed ".html.gz" (and, when built with -tags brotli,
+// ".html.br") files.
+func (bp *BatchProcessor) findHTMLFiles(dirPath string) ([]string, error) {
+	var htmlFiles []string
+
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip directories and non-HTML files
+		if info.IsDir() || !isHTMLPath(path) {
+			return nil
+		}
+
+		// Skip index.html files whose directory's parent is "." (only
+		// possible when dirPath is relative); with an absolute dirPath no
+		// index.html is skipped, including one directly under dirPath.
+		if strings.HasSuffix(strings.ToLower(stripIndexSuffix(info.Name())), "index.html") {
+			dir := filepath.Dir(path)
+			if filepath.Dir(dir) == "." || filepath.Dir(dir) == "" {
+				return nil
+			}
+		}
+
+		htmlFiles = append(htmlFiles, path)
+		return nil
+	})
+
+	return htmlFiles, err
+}
+
+// processFiles processes files concurrently
+func (bp *BatchProcessor) processFiles(ctx context.Context, filePaths []string) []BatchResult {
+	// Create channels for work distribution
+	fileChan := make(chan string, len(filePaths))
+	resultChan := make(chan BatchResult, len(filePaths))
+
+	// Start workers
+	var wg sync.WaitGroup
+	for i := 0; i < bp.options.MaxWorkers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			bp.worker(ctx, workerID, fileChan, resultChan)
+		}(i)
+	}
+
+	// Send files to workers
+	go func() {
+		defer close(fileChan)
+		for _, filePath := range filePaths {
+			select {
+			case fileChan <- filePath:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Close result channel when all workers are done
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	// Collect results
+	var results []BatchResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// processFilesStream is the streaming counterpart to processFiles: instead
+// of accumulating every BatchResult in memory, it hands results to the
+// caller as soon as a worker produces them. fileChan and resultChan are
+// both bounded by MaxWorkers, so a slow consumer applies backpressure all
+// the way back to the goroutine feeding file paths instead of letting every parsed
+// APIData/OpenAPISpec pile up at once.
+func (bp *BatchProcessor) processFilesStream(ctx context.Context, filePaths []string) (<-chan BatchResult, <-chan error) {
+	fileChan := make(chan string, bp.options.MaxWorkers)
+	resultChan := make(chan BatchResult, bp.options.MaxWorkers)
+	errChan := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < bp.options.MaxWorkers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			bp.worker(ctx, workerID, fileChan, resultChan)
+		}(i)
+	}
+
+	go func() {
+		defer close(fileChan)
+		for _, filePath := range filePaths {
+			select {
+			case fileChan <- filePath:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+		if err := bp.cache.save(); err != nil {
+			bp.logger.Warn("batch cache save failed", "dir", bp.options.CacheDir, "error", err)
+		}
+		if err := ctx.Err(); err != nil {
+			errChan <- err
+		}
+		close(errChan)
+	}()
+
+	return resultChan, errChan
+}
+
+// ProcessDirectoryStream is the streaming counterpart to ProcessDirectory:
+// it returns results as they're produced instead of accumulating a full
+// BatchReport, so callers processing the entire Novofon docs tree can
+// write each result's output and discard its APIData/OpenAPISpec before
+// the next one arrives. The returned error channel carries at most one
+// error (a failed directory walk, or ctx.Err() if ctx was canceled) and
+// is always closed once the result channel is drained.
+func (bp *BatchProcessor) ProcessDirectoryStream(ctx context.Context, dirPath string) (<-chan BatchResult, <-chan error) {
+	htmlFiles, err := bp.findHTMLFiles(dirPath)
+	if err != nil {
+		resultChan := make(chan BatchResult)
+		close(resultChan)
+		errChan := make(chan error, 1)
+		errChan <- fmt.Errorf("failed to find HTML files: %w", err)
+		close(errChan)
+		return resultChan, errChan
+	}
+
+	return bp.processFilesStream(ctx, htmlFiles)
+}
+
+// BatchIterator is a pull-style cursor over a BatchProcessor's streaming
+// results, for callers that prefer Next()/Err() over ranging a channel
+// directly.
+type BatchIterator struct {
+	resultChan <-chan BatchResult
+	errChan    <-chan error
+	cancel     context.CancelFunc
+	err        error
+}
+
+// NewBatchIterator starts processing paths with bp and returns an
+// iterator over the results, bounded by bp.options.MaxWorkers in flight
+// at once.
+func NewBatchIterator(ctx context.Context, bp *BatchProcessor, paths []string) *BatchIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	resultChan, errChan := bp.processFilesStream(ctx, paths)
+	return &BatchIterator{resultChan: resultChan, errChan: errChan, cancel: cancel}
+}
+
+// Next blocks until the next result is available, returning false once
+// every path has been processed.
+func (it *BatchIterator) Next() (BatchResult, bool) {
+	result, ok := <-it.resultChan
+	if !ok {
+		return BatchResult{}, false
+	}
+	return result, true
+}
+
+// Err returns the first error encountered (if any) once iteration has
+// finished. It should be checked after Next returns false.
+func (it *BatchIterator) Err() error {
+	if err, ok := <-it.errChan; ok {
+		it.err = err
+	}
+	return it.err
+}
+
+// Close stops iteration early, canceling any in-flight work.
+func (it *BatchIterator) Close() {
+	it.cancel()
+}
+
+// worker processes files from the input channel
+func (bp *BatchProcessor) worker(ctx context.Context, workerID int, fileChan <-chan string, resultChan chan<- BatchResult) {
+	for filePath := range fileChan {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			bp.metrics.incWorkers()
+			result := bp.processFile(filePath)
+			bp.metrics.decWorkers()
+			select {
+			case resultChan <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// processFile reads filePath from disk, transparently decompressing
+// ".html.gz"/".html.br" files, and runs the result through processDocument.
+func (bp *BatchProcessor) processFile(filePath string) BatchResult {
+	rawContent, err := os.ReadFile(filePath)
+	if err != nil {
+		return BatchResult{
+			FilePath: filePath,
+			Source:   filePath,
+			Error:    fmt.Sprintf("failed to read file: %v", err),
+		}
+	}
+
+	htmlContent, err := decompressHTML(filePath, rawContent)
+	if err != nil {
+		return BatchResult{
+			FilePath: filePath,
+			Source:   filePath,
+			Error:    fmt.Sprintf("failed to decompress file: %v", err),
+		}
+	}
+
+	return bp.processDocument(filePath, htmlContent)
+}
+
+// processDocument runs one document's HTML through parse -> generate ->
+// validate -> save, regardless of whether it came from disk (source is a
+// file path) or a live fetch (source is a URL). This is the shared core
+// processFile and the HTTP crawler's fetch workers both funnel into.
+func (bp *BatchProcessor) processDocument(source string, htmlContent []byte) BatchResult {
+	startTime := time.Now()
+	result := BatchResult{
+		FilePath: source,
+		Source:   source,
+		Success:  false,
+	}
+
+	defer func() {
+		result.ProcessTime = time.Since(startTime)
+		status := "error"
+		if result.Success {
+			status = "success"
+		}
+		bp.metrics.observeFile(status, result.ProcessTime)
+	}()
+
+	bp.logger.Info("parse_start", "source", source)
+
+	key := cacheKey(htmlContent)
+	if !bp.options.IgnoreCache {
+		if entry, ok := bp.cache.lookup(key); ok {
+			result.APIData = entry.APIData
+			result.OpenAPISpec = entry.OpenAPISpec
+			result.Validation = entry.Validation
+			result.Success = true
+			result.CacheHit = true
+			bp.logger.Info("parse_ok", "source", source, "cache_hit", true)
+			return result
+		}
+	}
+
+	// Parse HTML
+	parseStart := time.Now()
+	apiData, err := bp.parser.ParseHTML(string(htmlContent))
+	bp.metrics.observeParse(time.Since(parseStart))
+	if err != nil {
+		result.Error = fmt.Sprintf("parsing failed: %v", err)
+		bp.logger.Error("parse_fail", "source", source, "error", err)
+		return result
+	}
+
+	result.APIData = apiData
+	result.Success = true
+	bp.logger.Info("parse_ok", "source", source, "method", apiData.MethodInfo.Name)
+
+	// Generate OpenAPI spec if requested
+	if bp.options.GenerateOpenAPI {
+		spec, err := bp.generator.GenerateSpec(apiData)
+		if err != nil {
+			result.Error = fmt.Sprintf("OpenAPI generation failed: %v", err)
+			if !bp.options.SkipErrors {
+				return result
+			}
+		} else {
+			result.OpenAPISpec = spec
+		}
+	}
+
+	// Validate if requested
+	if bp.options.Validate {
+		validateStart := time.Now()
+		validation, err := bp.validator.ValidateAPIData(apiData)
+		bp.metrics.observeValidate(time.Since(validateStart))
+		if err != nil {
+			result.Error = fmt.Sprintf("validation failed: %v", err)
+			bp.logger.Error("validate_fail", "source", source, "error", err)
+			if !bp.options.SkipErrors {
+				return result
+			}
+		} else {
+			result.Validation = validation
+			if !validation.Valid {
+				bp.logger.Warn("validate_fail", "source", source, "method", apiData.MethodInfo.Name, "errors", len(validation.Errors))
+			}
+		}
+	}
+
+	// Save individual file output if output directory is specified
+	if bp.options.OutputDir != "" {
+		if err := bp.saveFileOutput(result); err != nil {
+			result.Error = fmt.Sprintf("failed to save output: %v", err)
+			if !bp.options.SkipErrors {
+				return result
+			}
+		}
+	}
+
+	if result.Success {
+		bp.cache.store(key, cacheEntry{
+			APIData:     result.APIData,
+			OpenAPISpec: result.OpenAPISpec,
+			Validation:  result.Validation,
+		})
+	}
+
+	return result
+}
+
+// saveFileOutput saves one file's output through every writer named in
+// bp.options.Format, so a single pass can emit several artifacts
+// (e.g. "json,postman") per processed file.
+func (bp *BatchProcessor) saveFileOutput(result BatchResult) error {
+	if !result.Success || result.APIData == nil {
+		return nil
+	}
+
+	// Generate output filename based on method name
+	methodName := result.APIData.MethodInfo.Name
+	if methodName == "" {
+		// Fallback to file basename if method name is empty
+		methodName = strings.TrimSuffix(filepath.Base(result.FilePath), ".html")
+	}
+
+	// Replace dots with underscores for valid filenames
+	safeMethodName := strings.ReplaceAll(methodName, ".", "_")
+
+	for _, name := range splitFormats(bp.options.Format) {
+		writer, ok := bp.writers[name]
+		if !ok {
+			return fmt.Errorf("unknown output format %q", name)
+		}
+
+		outputFile := filepath.Join(bp.options.OutputDir, safeMethodName+"."+writer.Extension())
+		if err := writeOutputFile(outputFile, writer, result); err != nil {
+			return fmt.Errorf("write %s output: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func writeOutputFile(outputFile string, writer OutputWriter, result BatchResult) error {
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+
+	writeErr := writer.Write(f, result)
+	closeErr := f.Close()
+	if writeErr != nil {
+		return writeErr
+	}
+	return closeErr
+}
+
+// generateReport creates a comprehensive processing report
+func (bp *BatchProcessor) generateReport(startTime time.Time, results []BatchResult) *BatchReport {
+	endTime := time.Now()
+
+	report := &BatchReport{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		TotalFiles: len(results),
+		TotalTime:  endTime.Sub(startTime),
+		Results:    results,
+	}
+
+	// Count successes and errors
+	var totalParams, totalErrors int
+	var apiMethods []string
+	var errorTypes []string
+	var fastestFile, slowestFile string
+	var fastestTime, slowestTime time.Duration
+	var cacheHits, cacheMisses int
+	var cacheBytesSaved int64
+
+	for _, result := range results {
+		if result.CacheHit {
+			cacheHits++
+			if info, err := os.Stat(result.FilePath); err == nil {
+				cacheBytesSaved += info.Size()
+			}
+		} else {
+			cacheMisses++
+		}
+
+		if result.Success {
+			report.SuccessCount++
+			if result.APIData != nil && result.APIData.MethodInfo != nil {
+				apiMethods = append(apiMethods, result.APIData.MethodInfo.Name)
+				totalParams += len(result.APIData.RequestParams) + len(result.APIData.ResponseParams)
+				if result.APIData.ErrorInfo != nil {
+					totalErrors += len(result.APIData.ErrorInfo.Errors)
+				}
+			}
+		} else {
+			report.ErrorCount++
+			if result.Error != "" {
+				errorTypes = append(errorTypes, result.Error)
+			}
+		}
+
+		// Track fastest and slowest files
+		if fastestFile == "" || result.ProcessTime < fastestTime {
+			fastestFile = result.FilePath
+			fastestTime = result.ProcessTime
+		}
+		if slowestFile == "" || result.ProcessTime > slowestTime {
+			slowestFile = result.FilePath
+			slowestTime = result.ProcessTime
+		}
+	}
+
+	// Calculate average time
+	var totalProcessTime time.Duration
+	for _, result := range results {
+		totalProcessTime += result.ProcessTime
+	}
+
+	report.Summary = BatchSummary{
+		APIMethods:      apiMethods,
+		ErrorTypes:      errorTypes,
+		AverageTime:     float64(totalProcessTime.Milliseconds()) / float64(len(results)),
+		FastestFile:     fastestFile,
+		SlowestFile:     slowestFile,
+		TotalParams:     totalParams,
+		TotalErrors:     totalErrors,
+		CacheHits:       cacheHits,
+		CacheMisses:     cacheMisses,
+		CacheBytesSaved: cacheBytesSaved,
+	}
+
+	return report
+}
+
+// saveReport saves the processing report as both JSON and a self-contained
+// HTML summary (batch_report.html) with per-file status, the slowest
+// files, an error-type histogram, and links to each method's output
+// artifacts.
+func (bp *BatchProcessor) saveReport(report *BatchReport) error {
+	if bp.options.OutputDir == "" {
+		return nil
+	}
+
+	reportFile := filepath.Join(bp.options.OutputDir, "batch_report.json")
+	reportData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(reportFile, reportData, 0644); err != nil {
+		return err
+	}
+
+	htmlData, err := bp.renderHTMLReport(report)
+	if err != nil {
+		return fmt.Errorf("render HTML report: %w", err)
+	}
+
+	htmlFile := filepath.Join(bp.options.OutputDir, "batch_report.html")
+	if err := os.WriteFile(htmlFile, htmlData, 0644); err != nil {
+		return err
+	}
+
+	if !bp.options.HTMLReport {
+		return nil
+	}
+
+	indexData, err := bp.renderIndexReport(report)
+	if err != nil {
+		return fmt.Errorf("render index report: %w", err)
+	}
+
+	indexFile := filepath.Join(bp.options.OutputDir, "index.html")
+	return os.WriteFile(indexFile, indexData, 0644)
+}
@@ -0,0 +1,119 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// ProcessDirectoryWithProgress is ProcessDirectory plus a live progress bar
+// written to out on every completed file, showing files/sec, ETA, the
+// configured worker count (MaxWorkers, not how many workers are busy), and
+// the last error seen. Intended for a TTY (e.g. os.Stderr);
+// out is written to with '\r' so each update overwrites the previous line.
+func (bp *BatchProcessor) ProcessDirectoryWithProgress(ctx context.Context, dirPath string, out io.Writer) (*BatchReport, error) {
+	startTime := time.Now()
+
+	htmlFiles, err := bp.findHTMLFiles(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find HTML files: %w", err)
+	}
+
+	if len(htmlFiles) == 0 {
+		return &BatchReport{
+			StartTime: startTime,
+			EndTime:   time.Now(),
+			TotalTime: time.Since(startTime),
+			Results:   []BatchResult{},
+			Summary:   BatchSummary{},
+		}, nil
+	}
+
+	if bp.options.OutputDir != "" {
+		if err := os.MkdirAll(bp.options.OutputDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	results, err := bp.processFilesWithProgress(ctx, htmlFiles, out, startTime)
+	if err != nil {
+		return nil, err
+	}
+
+	report := bp.generateReport(startTime, results)
+
+	if bp.options.GenerateReport {
+		if err := bp.saveReport(report); err != nil {
+			return nil, fmt.Errorf("failed to save report: %w", err)
+		}
+	}
+
+	if err := bp.cache.save(); err != nil {
+		bp.logger.Warn("batch cache save failed", "dir", bp.options.CacheDir, "error", err)
+	}
+
+	return report, nil
+}
+
+// processFilesWithProgress drains processFilesStream, rendering a progress
+// line to out after each result and returning every result once the stream
+// is exhausted.
+func (bp *BatchProcessor) processFilesWithProgress(ctx context.Context, filePaths []string, out io.Writer, startTime time.Time) ([]BatchResult, error) {
+	resultChan, errChan := bp.processFilesStream(ctx, filePaths)
+
+	total := len(filePaths)
+	results := make([]BatchResult, 0, total)
+	var lastErr string
+
+	for result := range resultChan {
+		results = append(results, result)
+		if !result.Success {
+			lastErr = result.Error
+		}
+		renderProgress(out, progressSnapshot{
+			Done:      len(results),
+			Total:     total,
+			Workers:   bp.options.MaxWorkers,
+			Elapsed:   time.Since(startTime),
+			LastError: lastErr,
+		})
+	}
+	fmt.Fprintln(out)
+
+	return results, <-errChan
+}
+
+// progressSnapshot is the state renderProgress needs to draw one line.
+type progressSnapshot struct {
+	Done      int
+	Total     int
+	Workers   int
+	Elapsed   time.Duration
+	LastError string
+}
+
+// renderProgress writes a single '\r'-prefixed progress line to out: a
+// fraction done, files/sec, ETA, worker count, and the most recent error
+// (truncated so the line stays a single row).
+func renderProgress(out io.Writer, s progressSnapshot) {
+	rate := float64(s.Done) / s.Elapsed.Seconds()
+	if s.Elapsed <= 0 {
+		rate = 0
+	}
+
+	var eta time.Duration
+	if rate > 0 {
+		eta = time.Duration(float64(s.Total-s.Done)/rate) * time.Second
+	}
+
+	lastErr := s.LastError
+	const maxErrLen = 60
+	if len(lastErr) > maxErrLen {
+		lastErr = lastErr[:maxErrLen-3] + "..."
+	}
+
+	fmt.Fprintf(out, "\r[%d/%d] %.1f files/s eta %s workers=%d last_error=%q",
+		s.Done, s.Total, rate, eta.Round(time.Second), s.Workers, lastErr)
+}
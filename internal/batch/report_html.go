@@ -0,0 +1,162 @@
+package batch
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// htmlReportTemplate renders a BatchReport as a single self-contained HTML
+// page (inline CSS, no external assets) so batch_report.html can be served
+// from any static bucket.
+var htmlReportTemplate = template.Must(template.New("batch_report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Batch Report</title>
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2rem; color: #1a1a1a; }
+h1, h2 { margin-top: 2rem; }
+table { border-collapse: collapse; width: 100%; margin-top: 0.5rem; }
+th, td { border: 1px solid #ddd; padding: 0.35rem 0.6rem; text-align: left; font-size: 0.9rem; }
+th { background: #f5f5f5; }
+.ok { color: #0a7a0a; }
+.fail { color: #b00020; }
+.summary { display: flex; gap: 2rem; flex-wrap: wrap; }
+.summary div { background: #f5f5f5; padding: 0.75rem 1rem; border-radius: 4px; }
+code { background: #f0f0f0; padding: 0 0.25rem; }
+</style>
+</head>
+<body>
+<h1>Batch Report</h1>
+<div class="summary">
+<div>Total files<br><strong>{{.Report.TotalFiles}}</strong></div>
+<div>Success<br><strong>{{.Report.SuccessCount}}</strong></div>
+<div>Errors<br><strong>{{.Report.ErrorCount}}</strong></div>
+<div>Cache hits<br><strong>{{.Report.Summary.CacheHits}}</strong></div>
+<div>Total time<br><strong>{{.Report.TotalTime}}</strong></div>
+<div>Average time<br><strong>{{printf "%.1f" .Report.Summary.AverageTime}} ms</strong></div>
+</div>
+
+<h2>Methods</h2>
+<table>
+<tr><th>Method</th><th>Artifacts</th></tr>
+{{range .Methods}}<tr><td><code>{{.Name}}</code></td><td>{{range .Links}}<a href="{{.}}">{{.}}</a> {{end}}</td></tr>
+{{end}}</table>
+
+<h2>Slowest files</h2>
+<table>
+<tr><th>File</th><th>Status</th><th>Process time</th></tr>
+{{range .Slowest}}<tr><td>{{.FilePath}}</td><td class="{{if .Success}}ok{{else}}fail{{end}}">{{if .Success}}ok{{else}}error{{end}}</td><td>{{.ProcessTime}}</td></tr>
+{{end}}</table>
+
+{{if .ErrorHistogram}}<h2>Error types</h2>
+<table>
+<tr><th>Error</th><th>Count</th></tr>
+{{range .ErrorHistogram}}<tr><td>{{.Error}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+{{end}}
+
+<h2>All files</h2>
+<table>
+<tr><th>File</th><th>Status</th><th>Process time</th><th>Cache hit</th></tr>
+{{range .Report.Results}}<tr><td>{{.FilePath}}</td><td class="{{if .Success}}ok{{else}}fail{{end}}">{{if .Success}}ok{{else}}{{.Error}}{{end}}</td><td>{{.ProcessTime}}</td><td>{{.CacheHit}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// htmlReportMethod is one row of the "Methods" table: a parsed method name
+// linking to the artifacts saveFileOutput wrote for it.
+type htmlReportMethod struct {
+	Name  string
+	Links []string
+}
+
+// htmlReportErrorCount is one row of the "Error types" histogram.
+type htmlReportErrorCount struct {
+	Error string
+	Count int
+}
+
+// htmlReportData is the top-level value passed to htmlReportTemplate.
+type htmlReportData struct {
+	Report         *BatchReport
+	Methods        []htmlReportMethod
+	Slowest        []BatchResult
+	ErrorHistogram []htmlReportErrorCount
+}
+
+// renderHTMLReport builds batch_report.html's contents for report.
+func (bp *BatchProcessor) renderHTMLReport(report *BatchReport) ([]byte, error) {
+	data := htmlReportData{
+		Report:         report,
+		Methods:        bp.htmlReportMethods(report),
+		Slowest:        slowestResults(report.Results, 10),
+		ErrorHistogram: errorHistogram(report.Summary.ErrorTypes),
+	}
+
+	var buf bytes.Buffer
+	if err := htmlReportTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// htmlReportMethods lists every successfully parsed method alongside the
+// output files saveFileOutput wrote for it, one per configured format.
+func (bp *BatchProcessor) htmlReportMethods(report *BatchReport) []htmlReportMethod {
+	var methods []htmlReportMethod
+	formats := splitFormats(bp.options.Format)
+
+	for _, result := range report.Results {
+		if !result.Success || result.APIData == nil || result.APIData.MethodInfo == nil {
+			continue
+		}
+		name := result.APIData.MethodInfo.Name
+		safeName := strings.ReplaceAll(name, ".", "_")
+
+		var links []string
+		for _, formatName := range formats {
+			if writer, ok := bp.writers[formatName]; ok {
+				links = append(links, safeName+"."+writer.Extension())
+			}
+		}
+		methods = append(methods, htmlReportMethod{Name: name, Links: links})
+	}
+
+	return methods
+}
+
+// slowestResults returns the n slowest results by ProcessTime, descending.
+func slowestResults(results []BatchResult, n int) []BatchResult {
+	sorted := make([]BatchResult, len(results))
+	copy(sorted, results)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ProcessTime > sorted[j].ProcessTime
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// errorHistogram counts how many times each distinct error message occurred
+// in errorTypes, sorted by count descending.
+func errorHistogram(errorTypes []string) []htmlReportErrorCount {
+	counts := make(map[string]int)
+	for _, e := range errorTypes {
+		counts[e]++
+	}
+
+	histogram := make([]htmlReportErrorCount, 0, len(counts))
+	for err, count := range counts {
+		histogram = append(histogram, htmlReportErrorCount{Error: err, Count: count})
+	}
+	sort.Slice(histogram, func(i, j int) bool {
+		return histogram[i].Count > histogram[j].Count
+	})
+
+	return histogram
+}
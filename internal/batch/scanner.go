@@ -0,0 +1,547 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ScannerOptions configures file scanning behavior
+type ScannerOptions struct {
+	Recursive    bool     // Scan subdirectories recursively
+	IncludeDirs  []string // Directories to include (empty = all)
+	ExcludeDirs  []string // Directories to exclude
+	FilePatterns []string // File patterns to match (e.g., "*.html")
+	MinDepth     int      // Minimum directory depth
+	MaxDepth     int      // Maximum directory depth (0 = unlimited)
+	SkipIndex    bool     // Skip index.html files
+	SkipAssets   bool     // Skip asset directories (css, js, images, etc.)
+	// Workers bounds how many goroutines classify and stat files
+	// concurrently. 0 defaults to runtime.NumCPU().
+	Workers int
+}
+
+// ScanResult represents the result of a directory scan
+type ScanResult struct {
+	TotalFiles   int      `json:"total_files"`
+	TotalDirs    int      `json:"total_dirs"`
+	HTMLFiles    []string `json:"html_files"`
+	SkippedFiles []string `json:"skipped_files"`
+	ErrorFiles   []string `json:"error_files"`
+	ScanTime     string   `json:"scan_time"`
+	Directories  []string `json:"directories"`
+}
+
+// DirectoryScanner scans directories for HTML files
+type DirectoryScanner struct {
+	options *ScannerOptions
+	// rootPath is set for the duration of a scan so shouldSkipDirectory can
+	// compute MinDepth/MaxDepth relative to the scan root rather than the
+	// absolute path, which depends on the caller's working directory.
+	rootPath string
+}
+
+// NewDirectoryScanner creates a new directory scanner
+func NewDirectoryScanner(options *ScannerOptions) *DirectoryScanner {
+	if options == nil {
+		options = &ScannerOptions{
+			Recursive:    true,
+			FilePatterns: []string{"*.html"},
+			SkipIndex:    true,
+			SkipAssets:   true,
+		}
+	}
+
+	return &DirectoryScanner{
+		options: options,
+	}
+}
+
+// ScanDirectory scans a directory for HTML files. File classification and
+// stat work is fanned out across a bounded worker pool (ScannerOptions.Workers,
+// default runtime.NumCPU()), since the directory walk itself is too cheap to
+// parallelize but classifying thousands of files serially is not.
+func (ds *DirectoryScanner) ScanDirectory(rootPath string) (*ScanResult, error) {
+	result := &ScanResult{
+		HTMLFiles:    []string{},
+		SkippedFiles: []string{},
+		ErrorFiles:   []string{},
+		Directories:  []string{},
+	}
+
+	// Check if root path exists
+	if _, err := os.Stat(rootPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("directory does not exist: %s", rootPath)
+	}
+
+	ds.rootPath = rootPath
+
+	jobs := make(chan walkEntry, ds.workerCount())
+	events := make(chan FileEvent, ds.workerCount())
+
+	var workers sync.WaitGroup
+	for i := 0; i < ds.workerCount(); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for entry := range jobs {
+				events <- ds.classify(entry.path, entry.info, rootPath)
+			}
+		}()
+	}
+
+	walkErrCh := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		walkErrCh <- filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				result.ErrorFiles = append(result.ErrorFiles, path)
+				return nil // Continue processing other files
+			}
+
+			if info.IsDir() {
+				result.TotalDirs++
+				result.Directories = append(result.Directories, path)
+
+				if path != rootPath && ds.shouldSkipDirectory(path, info) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			jobs <- walkEntry{path: path, info: info}
+			return nil
+		})
+	}()
+
+	go func() {
+		workers.Wait()
+		close(events)
+	}()
+
+	for event := range events {
+		result.TotalFiles++
+		switch event.Kind {
+		case FileEventHTML:
+			result.HTMLFiles = append(result.HTMLFiles, event.Path)
+		case FileEventSkipped:
+			result.SkippedFiles = append(result.SkippedFiles, event.Path)
+		case FileEventError:
+			result.ErrorFiles = append(result.ErrorFiles, event.Path)
+		}
+	}
+
+	if err := <-walkErrCh; err != nil {
+		return nil, fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	return result, nil
+}
+
+// walkEntry is one non-directory file handed from the walking goroutine to
+// the classification worker pool.
+type walkEntry struct {
+	path string
+	info os.FileInfo
+}
+
+// FileEventKind discriminates the events ScanDirectoryStream emits.
+type FileEventKind string
+
+const (
+	FileEventHTML    FileEventKind = "html"
+	FileEventSkipped FileEventKind = "skipped"
+	FileEventError   FileEventKind = "error"
+)
+
+// FileEvent is one file's classification result, emitted by
+// ScanDirectoryStream as soon as a worker finishes it, rather than after the
+// whole tree has been walked.
+type FileEvent struct {
+	Path     string
+	Size     int64
+	Category string
+	Kind     FileEventKind
+	Err      error
+}
+
+// ScanDirectoryStream walks rootPath the same way ScanDirectory does, but
+// invokes cb for each file's FileEvent as soon as a worker finishes
+// classifying it, so a downstream consumer (the schema generator, the lint
+// command) can start processing before the walk completes. The walk and
+// every worker honor ctx: cancelling it stops the scan early. If cb returns
+// an error, the scan is drained and that error is returned.
+func (ds *DirectoryScanner) ScanDirectoryStream(ctx context.Context, rootPath string, cb func(FileEvent) error) error {
+	if _, err := os.Stat(rootPath); os.IsNotExist(err) {
+		return fmt.Errorf("directory does not exist: %s", rootPath)
+	}
+
+	ds.rootPath = rootPath
+
+	jobs := make(chan walkEntry, ds.workerCount())
+	events := make(chan FileEvent, ds.workerCount())
+
+	var workers sync.WaitGroup
+	for i := 0; i < ds.workerCount(); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for entry := range jobs {
+				select {
+				case events <- ds.classify(entry.path, entry.info, rootPath):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	walkErrCh := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		walkErrCh <- filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				select {
+				case jobs <- walkEntry{path: path, info: nil}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				return nil
+			}
+
+			if info.IsDir() {
+				if path != rootPath && ds.shouldSkipDirectory(path, info) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			select {
+			case jobs <- walkEntry{path: path, info: info}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return nil
+		})
+	}()
+
+	go func() {
+		workers.Wait()
+		close(events)
+	}()
+
+	var cbErr error
+	for event := range events {
+		if cbErr != nil || ctx.Err() != nil {
+			continue // drain so the walk and workers can exit cleanly
+		}
+		if err := cb(event); err != nil {
+			cbErr = err
+		}
+	}
+
+	if cbErr != nil {
+		return cbErr
+	}
+	if err := <-walkErrCh; err != nil && err != context.Canceled {
+		return fmt.Errorf("failed to scan directory: %w", err)
+	}
+	return ctx.Err()
+}
+
+// classify turns one walked file into a FileEvent. info is nil when the
+// walk itself failed to stat path.
+func (ds *DirectoryScanner) classify(path string, info os.FileInfo, rootPath string) FileEvent {
+	if info == nil {
+		return FileEvent{Path: path, Kind: FileEventError}
+	}
+
+	if !ds.isHTMLFile(path, info) || ds.shouldSkipFile(path, info) {
+		return FileEvent{Path: path, Size: info.Size(), Kind: FileEventSkipped}
+	}
+
+	return FileEvent{
+		Path:     path,
+		Size:     info.Size(),
+		Category: ds.extractCategory(path, rootPath),
+		Kind:     FileEventHTML,
+	}
+}
+
+// workerCount returns the configured classification concurrency, defaulting
+// to runtime.NumCPU() when ScannerOptions.Workers is unset.
+func (ds *DirectoryScanner) workerCount() int {
+	if ds.options.Workers > 0 {
+		return ds.options.Workers
+	}
+	if n := runtime.NumCPU(); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// isHTMLFile checks if a file is an HTML file
+func (ds *DirectoryScanner) isHTMLFile(path string, info os.FileInfo) bool {
+	// Check file extension
+	if !strings.HasSuffix(strings.ToLower(path), ".html") {
+		return false
+	}
+
+	// Check file patterns if specified
+	if len(ds.options.FilePatterns) > 0 {
+		matched := false
+		for _, pattern := range ds.options.FilePatterns {
+			if ok, _ := filepath.Match(pattern, info.Name()); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// shouldSkipFile determines if a file should be skipped
+func (ds *DirectoryScanner) shouldSkipFile(path string, info os.FileInfo) bool {
+	fileName := strings.ToLower(info.Name())
+
+	// Skip index files if requested. Only an index.html whose directory's
+	// parent is "." (e.g. "docs/index.html" for a relative path) is skipped;
+	// index.html files under an absolute path or in deeper directories are kept.
+	if ds.options.SkipIndex && fileName == "index.html" {
+		dir := filepath.Dir(path)
+		if filepath.Dir(dir) == "." || filepath.Dir(dir) == "" {
+			return true
+		}
+	}
+
+	// Skip 404 files
+	if fileName == "404.html" {
+		return true
+	}
+
+	// Skip files in asset directories
+	if ds.options.SkipAssets {
+		dir := filepath.Dir(path)
+		dirName := strings.ToLower(filepath.Base(dir))
+		assetDirs := []string{"assets", "css", "js", "javascripts", "stylesheets", "images", "img"}
+		for _, assetDir := range assetDirs {
+			if dirName == assetDir {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// shouldSkipDirectory determines if a directory should be skipped
+func (ds *DirectoryScanner) shouldSkipDirectory(path string, info os.FileInfo) bool {
+	dirName := strings.ToLower(info.Name())
+
+	// Skip hidden directories
+	if strings.HasPrefix(dirName, ".") {
+		return true
+	}
+
+	// Skip asset directories if requested
+	if ds.options.SkipAssets {
+		assetDirs := []string{"assets", "css", "js", "javascripts", "stylesheets", "images", "img", "node_modules", "vendor"}
+		for _, assetDir := range assetDirs {
+			if dirName == assetDir {
+				return true
+			}
+		}
+	}
+
+	// Check exclude directories
+	for _, excludeDir := range ds.options.ExcludeDirs {
+		if strings.Contains(path, excludeDir) {
+			return true
+		}
+	}
+
+	// Check include directories (if specified)
+	if len(ds.options.IncludeDirs) > 0 {
+		included := false
+		for _, includeDir := range ds.options.IncludeDirs {
+			if strings.Contains(path, includeDir) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return true
+		}
+	}
+
+	// Check depth limits
+	if ds.options.MaxDepth > 0 {
+		depth := ds.relativeDepth(path)
+		if depth > ds.options.MaxDepth {
+			return true
+		}
+	}
+
+	if ds.options.MinDepth > 0 {
+		depth := ds.relativeDepth(path)
+		if depth < ds.options.MinDepth {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relativeDepth is getDepth, but relative to the scan's rootPath rather than
+// absolute: MinDepth/MaxDepth are meant to bound how far below the scan
+// root a directory sits, not its absolute path depth, which varies with the
+// caller's working directory. Falls back to getDepth when called outside an
+// active scan (rootPath unset), e.g. from a direct unit test.
+func (ds *DirectoryScanner) relativeDepth(path string) int {
+	if ds.rootPath == "" {
+		return ds.getDepth(path)
+	}
+
+	rel, err := filepath.Rel(ds.rootPath, path)
+	if err != nil {
+		return ds.getDepth(path)
+	}
+	if rel == "." {
+		return 0
+	}
+
+	return ds.getDepth(rel)
+}
+
+// getDepth calculates the directory depth
+func (ds *DirectoryScanner) getDepth(path string) int {
+	parts := strings.Split(path, string(filepath.Separator))
+	depth := 0
+	for _, part := range parts {
+		if part != "" && part != "." {
+			depth++
+		}
+	}
+	return depth
+}
+
+// GetAPICategories scans and categorizes HTML files by API type
+func (ds *DirectoryScanner) GetAPICategories(rootPath string) (map[string][]string, error) {
+	result, err := ds.ScanDirectory(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string][]string)
+
+	for _, filePath := range result.HTMLFiles {
+		// Extract category from path
+		category := ds.extractCategory(filePath, rootPath)
+		categories[category] = append(categories[category], filePath)
+	}
+
+	return categories, nil
+}
+
+// extractCategory extracts the API category from a file path
+func (ds *DirectoryScanner) extractCategory(filePath, rootPath string) string {
+	// Remove root path
+	relPath, err := filepath.Rel(rootPath, filePath)
+	if err != nil {
+		return "unknown"
+	}
+
+	// Split path into parts
+	parts := strings.Split(relPath, string(filepath.Separator))
+
+	// Find the first meaningful directory (skip empty parts)
+	for _, part := range parts {
+		if part != "" && part != "." {
+			// Clean up the category name
+			category := strings.ReplaceAll(part, "_", " ")
+			category = strings.Title(category)
+			return category
+		}
+	}
+
+	return "root"
+}
+
+// GetFileStats provides statistics about the scanned files. It streams
+// classification results straight off the worker pool rather than, as
+// before, re-os.Stat-ing every HTML file in a second serial pass: the size
+// ScanDirectoryStream's walk already read is reused directly.
+func (ds *DirectoryScanner) GetFileStats(rootPath string) (*FileStats, error) {
+	stats := &FileStats{
+		Categories: make(map[string]int),
+	}
+
+	var mu sync.Mutex
+	var totalDirs int
+	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.IsDir() {
+			totalDirs++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan directory: %w", err)
+	}
+	stats.TotalDirs = totalDirs
+
+	err = ds.ScanDirectoryStream(context.Background(), rootPath, func(event FileEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		stats.TotalFiles++
+		switch event.Kind {
+		case FileEventHTML:
+			stats.HTMLFiles++
+			stats.Categories[event.Category]++
+
+			if stats.LargestFile == "" || event.Size > stats.LargestSize {
+				stats.LargestFile = event.Path
+				stats.LargestSize = event.Size
+			}
+			if stats.SmallestFile == "" || event.Size < stats.SmallestSize {
+				stats.SmallestFile = event.Path
+				stats.SmallestSize = event.Size
+			}
+		case FileEventSkipped:
+			stats.SkippedFiles++
+		case FileEventError:
+			stats.ErrorFiles++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// FileStats provides file statistics
+type FileStats struct {
+	TotalFiles   int            `json:"total_files"`
+	TotalDirs    int            `json:"total_dirs"`
+	HTMLFiles    int            `json:"html_files"`
+	SkippedFiles int            `json:"skipped_files"`
+	ErrorFiles   int            `json:"error_files"`
+	Categories   map[string]int `json:"categories"`
+	LargestFile  string         `json:"largest_file"`
+	SmallestFile string         `json:"smallest_file"`
+	LargestSize  int64          `json:"largest_size"`
+	SmallestSize int64          `json:"smallest_size"`
+}
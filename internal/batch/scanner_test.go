@@ -1,9 +1,13 @@
 package batch
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -315,6 +319,106 @@ func TestDirectoryScanner_GetDepth(t *testing.T) {
 	}
 }
 
+func TestDirectoryScanner_ScanDirectory_MultipleWorkers(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for i := 0; i < 20; i++ {
+		name := filepath.Join(tempDir, "page"+strings.Repeat("x", i)+".html")
+		if err := os.WriteFile(name, []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", name, err)
+		}
+	}
+
+	scanner := NewDirectoryScanner(&ScannerOptions{
+		Recursive:  true,
+		SkipIndex:  false,
+		SkipAssets: false,
+		Workers:    4,
+	})
+
+	result, err := scanner.ScanDirectory(tempDir)
+	if err != nil {
+		t.Fatalf("ScanDirectory failed: %v", err)
+	}
+
+	if len(result.HTMLFiles) != 20 {
+		t.Errorf("Expected 20 HTML files, got %d", len(result.HTMLFiles))
+	}
+}
+
+func TestDirectoryScanner_ScanDirectoryStream_ContextCancellation(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for i := 0; i < 50; i++ {
+		name := filepath.Join(tempDir, "page"+strings.Repeat("x", i)+".html")
+		if err := os.WriteFile(name, []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", name, err)
+		}
+	}
+
+	scanner := NewDirectoryScanner(&ScannerOptions{
+		Recursive: true,
+		Workers:   1,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var seen int32
+	err := scanner.ScanDirectoryStream(ctx, tempDir, func(event FileEvent) error {
+		if atomic.AddInt32(&seen, 1) == 1 {
+			cancel()
+		}
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if int(atomic.LoadInt32(&seen)) >= 50 {
+		t.Error("Expected cancellation to stop the scan before every file was visited")
+	}
+}
+
+func TestDirectoryScanner_ScanDirectoryStream_CallbackErrorDrainsEarly(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for i := 0; i < 50; i++ {
+		name := filepath.Join(tempDir, "page"+strings.Repeat("x", i)+".html")
+		if err := os.WriteFile(name, []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", name, err)
+		}
+	}
+
+	scanner := NewDirectoryScanner(&ScannerOptions{
+		Recursive: true,
+		Workers:   1,
+	})
+
+	wantErr := errors.New("callback stopped early")
+
+	var mu sync.Mutex
+	var seen int
+	err := scanner.ScanDirectoryStream(context.Background(), tempDir, func(event FileEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen++
+		if seen == 1 {
+			return wantErr
+		}
+		return nil
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Expected %v, got %v", wantErr, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if seen >= 50 {
+		t.Error("Expected the callback error to drain the scan before every file was visited")
+	}
+}
+
 // Mock file info for testing
 type mockFileInfo struct {
 	name string
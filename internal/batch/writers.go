@@ -0,0 +1,176 @@
+package batch
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputWriter renders one BatchResult as a single artifact. BatchOptions.
+// Format names the writers a batch run invokes for each processed file,
+// so one pass can emit several artifacts (e.g. "json,postman") instead of
+// requiring a separate run per format.
+type OutputWriter interface {
+	// Name is the identifier BatchOptions.Format selects this writer by.
+	Name() string
+	// Extension is the file extension (without a leading dot) saveFileOutput
+	// appends to the method name, e.g. "yaml" or "postman_collection.json".
+	Extension() string
+	// Write renders result to w.
+	Write(w io.Writer, result BatchResult) error
+}
+
+// RegisterWriter adds or replaces an output writer, keyed by its Name().
+func (bp *BatchProcessor) RegisterWriter(writer OutputWriter) {
+	bp.writers[writer.Name()] = writer
+}
+
+// registerBuiltinWriters wires up every writer NewBatchProcessor ships by
+// default.
+func (bp *BatchProcessor) registerBuiltinWriters() {
+	bp.RegisterWriter(jsonWriter{})
+	bp.RegisterWriter(yamlWriter{})
+	bp.RegisterWriter(openapiWriter{bp: bp})
+	bp.RegisterWriter(openapi31Writer{bp: bp})
+	bp.RegisterWriter(jsonSchemaWriter{bp: bp})
+	bp.RegisterWriter(postmanWriter{})
+}
+
+// splitFormats parses BatchOptions.Format into the writer names a file
+// should be saved through, defaulting to "json" when empty.
+func splitFormats(format string) []string {
+	var formats []string
+	for _, part := range strings.Split(format, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			formats = append(formats, part)
+		}
+	}
+	if len(formats) == 0 {
+		formats = []string{"json"}
+	}
+	return formats
+}
+
+// jsonWriter writes a result's parsed APIData as indented JSON.
+type jsonWriter struct{}
+
+func (jsonWriter) Name() string      { return "json" }
+func (jsonWriter) Extension() string { return "json" }
+
+func (jsonWriter) Write(w io.Writer, result BatchResult) error {
+	data, err := json.MarshalIndent(result.APIData, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// yamlWriter writes a result's parsed APIData as YAML.
+type yamlWriter struct{}
+
+func (yamlWriter) Name() string      { return "yaml" }
+func (yamlWriter) Extension() string { return "yaml" }
+
+func (yamlWriter) Write(w io.Writer, result BatchResult) error {
+	data, err := yaml.Marshal(result.APIData)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// openapiWriter writes a result's OpenAPI 3.0-shaped spec as YAML,
+// generating it first if GenerateOpenAPI wasn't already enabled.
+type openapiWriter struct{ bp *BatchProcessor }
+
+func (openapiWriter) Name() string      { return "openapi" }
+func (openapiWriter) Extension() string { return "yaml" }
+
+func (w openapiWriter) Write(out io.Writer, result BatchResult) error {
+	spec := result.OpenAPISpec
+	if spec == nil {
+		var err error
+		spec, err = w.bp.generator.GenerateSpec(result.APIData)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := spec.ToYAML()
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// openapi31Writer is openapiWriter with the document pinned to OpenAPI
+// 3.1.0 instead of the generator's native 3.0-shaped output.
+type openapi31Writer struct{ bp *BatchProcessor }
+
+func (openapi31Writer) Name() string      { return "openapi31" }
+func (openapi31Writer) Extension() string { return "yaml" }
+
+func (w openapi31Writer) Write(out io.Writer, result BatchResult) error {
+	spec := result.OpenAPISpec
+	if spec == nil {
+		var err error
+		spec, err = w.bp.generator.GenerateSpec(result.APIData)
+		if err != nil {
+			return err
+		}
+	}
+
+	spec31 := *spec
+	spec31.OpenAPI = "3.1.0"
+
+	data, err := spec31.ToYAML()
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// jsonSchemaWriter writes the JSON-RPC request schema GenerateSchemaFromAPIData
+// produces for this method, with any registered shared definitions
+// already inlined under "$defs".
+type jsonSchemaWriter struct{ bp *BatchProcessor }
+
+func (jsonSchemaWriter) Name() string      { return "jsonschema" }
+func (jsonSchemaWriter) Extension() string { return "schema.json" }
+
+func (w jsonSchemaWriter) Write(out io.Writer, result BatchResult) error {
+	schema, err := w.bp.validator.GenerateSchemaFromAPIData(result.APIData)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// postmanWriter writes a Postman v2.1 collection containing a single
+// request for this method, built from its parsed JSON-RPC example.
+type postmanWriter struct{}
+
+func (postmanWriter) Name() string      { return "postman" }
+func (postmanWriter) Extension() string { return "postman_collection.json" }
+
+func (postmanWriter) Write(out io.Writer, result BatchResult) error {
+	collection := newPostmanCollection(result.APIData)
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
@@ -0,0 +1,278 @@
+// Package compile resolves the generated per-method OpenAPI files (one path
+// each, written by generate.OpenAPIGenerator.GenerateSpec) into versioned
+// bundles. Each method file carries an x-novofon-version/x-novofon-stability
+// stamp; Compiler groups same-operation files across versions and picks a
+// winner per target pin using the same "pivot date" rule Vervet uses: the
+// newest non-"wip" version that is no newer than the pin.
+package compile
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xcono/novofon/internal/generate"
+	"gopkg.in/yaml.v3"
+)
+
+// StabilityWIP is excluded from version resolution: work-in-progress
+// operations never win a pin, including "latest".
+const StabilityWIP = "wip"
+
+// MethodSpec is one generated per-method OpenAPI file together with the
+// version metadata its single operation was stamped with.
+type MethodSpec struct {
+	// Path is the source file this was loaded from.
+	Path string
+	// OperationID is the spec's single path key, e.g. "/get_contacts".
+	OperationID string
+	Version     string
+	Stability   string
+
+	spec *generate.OpenAPISpec
+	verb string
+}
+
+// LoadMethodSpec reads one generated per-method YAML file.
+func LoadMethodSpec(path string) (*MethodSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var spec generate.OpenAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for opID, item := range spec.Paths {
+		op, verb := operationIn(item)
+		if op == nil {
+			continue
+		}
+		return &MethodSpec{
+			Path:        path,
+			OperationID: opID,
+			Version:     op.XVersion,
+			Stability:   op.XStability,
+			spec:        &spec,
+			verb:        verb,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no operation found in %s", path)
+}
+
+// operationIn returns item's single populated operation and the HTTP verb it
+// was found under.
+func operationIn(item generate.PathItem) (*generate.Operation, string) {
+	switch {
+	case item.Get != nil:
+		return item.Get, "get"
+	case item.Post != nil:
+		return item.Post, "post"
+	case item.Put != nil:
+		return item.Put, "put"
+	case item.Delete != nil:
+		return item.Delete, "delete"
+	default:
+		return nil, ""
+	}
+}
+
+// compareVersions orders two version strings the way Resolve needs: date
+// versions ("2024-05-01") already compare correctly as plain strings, but
+// semver versions ("1.10.0") don't - "1.10.0" < "1.9.0" lexicographically,
+// which would make Resolve pick a stale version. When both sides parse as
+// dot-separated numeric segments (semver), they're compared numerically
+// per-segment instead; otherwise this falls back to a plain string compare.
+func compareVersions(a, b string) int {
+	as, aOK := parseSemver(a)
+	bs, bOK := parseSemver(b)
+	if !aOK || !bOK {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv int
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+		if av != bv {
+			if av < bv {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseSemver splits version into its dot-separated numeric segments (e.g.
+// "1.10.0" -> [1, 10, 0]), reporting ok=false for anything that isn't
+// purely numeric segments, like a "2024-05-01" date version.
+func parseSemver(version string) (segments []int, ok bool) {
+	parts := strings.Split(version, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		nums[i] = n
+	}
+	return nums, true
+}
+
+// Resolver picks the winning MethodSpec version for each operation ID.
+type Resolver struct {
+	byOperation map[string][]*MethodSpec
+}
+
+// NewResolver groups specs (possibly spanning several versions of the same
+// operation) by OperationID.
+func NewResolver(specs []*MethodSpec) *Resolver {
+	r := &Resolver{byOperation: make(map[string][]*MethodSpec)}
+	for _, s := range specs {
+		r.byOperation[s.OperationID] = append(r.byOperation[s.OperationID], s)
+	}
+	return r
+}
+
+// Resolve returns the winning MethodSpec for every known operation: the
+// newest version that is <= pin (or the newest version of all, when pin is
+// ""  i.e. "latest"), skipping StabilityWIP. An operation with no eligible
+// version (every version is "wip", or newer than pin) is omitted. Each
+// winner's operation is stamped with x-novofon-source recording which file
+// it came from.
+func (r *Resolver) Resolve(pin string) []*MethodSpec {
+	operationIDs := make([]string, 0, len(r.byOperation))
+	for opID := range r.byOperation {
+		operationIDs = append(operationIDs, opID)
+	}
+	sort.Strings(operationIDs)
+
+	resolved := make([]*MethodSpec, 0, len(operationIDs))
+	for _, opID := range operationIDs {
+		versions := append([]*MethodSpec(nil), r.byOperation[opID]...)
+		sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i].Version, versions[j].Version) < 0 })
+
+		var winner *MethodSpec
+		for _, v := range versions {
+			if v.Stability == StabilityWIP {
+				continue
+			}
+			if pin != "" && compareVersions(v.Version, pin) > 0 {
+				continue
+			}
+			winner = v
+		}
+		if winner == nil {
+			continue
+		}
+
+		stampSource(winner)
+		resolved = append(resolved, winner)
+	}
+
+	return resolved
+}
+
+// stampSource records winner.Path on its operation's x-novofon-source
+// extension, for traceability once it's merged into a pinned bundle.
+func stampSource(winner *MethodSpec) {
+	item := winner.spec.Paths[winner.OperationID]
+	op, _ := operationIn(item)
+	if op != nil {
+		op.XSource = winner.Path
+	}
+}
+
+// Compiler builds and writes versioned bundles for one (domain, apiType)
+// group of MethodSpecs.
+type Compiler struct {
+	Title       string
+	Description string
+	Specs       []*MethodSpec
+
+	// FileName renders the output path for a given version pin (or
+	// "latest"). Required.
+	FileName func(version string) string
+}
+
+// NewCompiler builds a Compiler over an already-loaded set of MethodSpecs,
+// e.g. every version of every operation in one domain/apiType group.
+func NewCompiler(title, description string, specs []*MethodSpec, fileName func(version string) string) *Compiler {
+	return &Compiler{Title: title, Description: description, Specs: specs, FileName: fileName}
+}
+
+// CompileVersions resolves and writes one bundle per requested version pin.
+// A pin with no eligible operations (every version is "wip", or newer than
+// the pin) is skipped rather than erroring, since not every target has a
+// method that old.
+func (c *Compiler) CompileVersions(pins []string) error {
+	resolver := NewResolver(c.Specs)
+
+	for _, pin := range pins {
+		resolved := resolver.Resolve(pin)
+		if len(resolved) == 0 {
+			continue
+		}
+		if err := c.write(pin, resolved); err != nil {
+			return fmt.Errorf("compile version %s: %w", pin, err)
+		}
+	}
+	return nil
+}
+
+// CompileLatest resolves and writes the "latest" bundle: the newest
+// non-wip version of every operation, regardless of any explicit pin.
+func (c *Compiler) CompileLatest() error {
+	resolver := NewResolver(c.Specs)
+	resolved := resolver.Resolve("")
+	if len(resolved) == 0 {
+		return nil
+	}
+	if err := c.write("latest", resolved); err != nil {
+		return fmt.Errorf("compile latest: %w", err)
+	}
+	return nil
+}
+
+func (c *Compiler) write(version string, resolved []*MethodSpec) error {
+	bundle := &generate.OpenAPISpec{
+		OpenAPI: "3.0.0",
+		Info: generate.OpenAPIInfo{
+			Title:       c.Title,
+			Version:     version,
+			Description: c.Description,
+		},
+		Paths: make(map[string]generate.PathItem),
+	}
+	for _, spec := range resolved {
+		bundle.Paths[spec.OperationID] = spec.spec.Paths[spec.OperationID]
+	}
+
+	data, err := bundle.ToYAML()
+	if err != nil {
+		return fmt.Errorf("marshal bundle: %w", err)
+	}
+
+	outputFile := c.FileName(version)
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", outputFile, err)
+	}
+	return nil
+}
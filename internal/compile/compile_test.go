@@ -0,0 +1,127 @@
+package compile
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/generate"
+)
+
+func TestCompareVersions_Semver(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.9.0", "1.10.0", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.2.3", "1.2.3", 0},
+		{"2.0.0", "1.99.99", 1},
+		{"1.2", "1.2.0", 0}, // missing trailing segments are treated as 0
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompareVersions_Dates(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2024-05-01", "2024-06-01", -1},
+		{"2024-06-01", "2024-05-01", 1},
+		{"2024-05-01", "2024-05-01", 0},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func newSpec(operationID, version, stability string) *MethodSpec {
+	return &MethodSpec{
+		OperationID: operationID,
+		Version:     version,
+		Stability:   stability,
+		spec: &generate.OpenAPISpec{
+			Paths: map[string]generate.PathItem{
+				operationID: {Post: &generate.Operation{XVersion: version, XStability: stability}},
+			},
+		},
+		verb: "post",
+	}
+}
+
+func TestResolver_Resolve_PicksHighestSemverNotLexicallyLast(t *testing.T) {
+	specs := []*MethodSpec{
+		newSpec("/get_contacts", "1.9.0", ""),
+		newSpec("/get_contacts", "1.10.0", ""),
+	}
+
+	r := NewResolver(specs)
+	resolved := r.Resolve("")
+	if len(resolved) != 1 {
+		t.Fatalf("expected 1 resolved operation, got %d", len(resolved))
+	}
+	if resolved[0].Version != "1.10.0" {
+		t.Errorf("expected 1.10.0 to win as the newest version, got %s", resolved[0].Version)
+	}
+}
+
+func TestResolver_Resolve_PinExcludesNewerSemver(t *testing.T) {
+	specs := []*MethodSpec{
+		newSpec("/get_contacts", "1.9.0", ""),
+		newSpec("/get_contacts", "1.10.0", ""),
+	}
+
+	r := NewResolver(specs)
+	resolved := r.Resolve("1.9.5")
+	if len(resolved) != 1 {
+		t.Fatalf("expected 1 resolved operation, got %d", len(resolved))
+	}
+	if resolved[0].Version != "1.9.0" {
+		t.Errorf("expected the pin to exclude 1.10.0 and keep 1.9.0, got %s", resolved[0].Version)
+	}
+}
+
+func TestResolver_Resolve_SkipsWIP(t *testing.T) {
+	specs := []*MethodSpec{
+		newSpec("/get_contacts", "1.9.0", ""),
+		newSpec("/get_contacts", "1.10.0", StabilityWIP),
+	}
+
+	r := NewResolver(specs)
+	resolved := r.Resolve("")
+	if len(resolved) != 1 || resolved[0].Version != "1.9.0" {
+		t.Fatalf("expected wip 1.10.0 to be skipped, leaving 1.9.0, got %+v", resolved)
+	}
+}
+
+func TestResolver_Resolve_NoEligibleVersionIsOmitted(t *testing.T) {
+	specs := []*MethodSpec{
+		newSpec("/get_contacts", "2.0.0", StabilityWIP),
+	}
+
+	r := NewResolver(specs)
+	resolved := r.Resolve("")
+	if len(resolved) != 0 {
+		t.Fatalf("expected no resolved operations when every version is wip, got %+v", resolved)
+	}
+}
+
+func TestResolver_Resolve_DateVersionsStillWork(t *testing.T) {
+	specs := []*MethodSpec{
+		newSpec("/get_contacts", "2024-05-01", ""),
+		newSpec("/get_contacts", "2024-06-01", ""),
+	}
+
+	r := NewResolver(specs)
+	resolved := r.Resolve("2024-05-15")
+	if len(resolved) != 1 || resolved[0].Version != "2024-05-01" {
+		t.Fatalf("expected the 2024-05-15 pin to keep 2024-05-01, got %+v", resolved)
+	}
+}
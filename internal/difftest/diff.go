@@ -0,0 +1,121 @@
+// Package difftest implements a small, dependency-free line-oriented diff
+// for test golden-file comparisons: a classic LCS table followed by a
+// unified-diff-style render with +/- markers and a few lines of
+// surrounding context, so a golden mismatch reads as a readable diff
+// instead of two giant JSON blobs.
+package difftest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// opKind is one line's role in the diff.
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns a unified-diff-style rendering of the changes needed to
+// turn a into b: "  " for unchanged lines, "- " for lines only in a,
+// "+ " for lines only in b, with context lines of unchanged padding kept
+// around each changed region and "..." marking the lines skipped between.
+func Unified(a, b []string, context int) string {
+	return render(diffOps(a, b), context)
+}
+
+// diffOps computes the longest common subsequence of a and b via dynamic
+// programming, then backtracks it into a flat list of equal/delete/insert
+// operations in a-then-b order.
+func diffOps(a, b []string) []op {
+	n, m := len(a), len(b)
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{opDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{opDelete, a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+	return ops
+}
+
+// render collapses runs of unchanged ops down to context lines of padding
+// around each changed region, formatting what remains as a unified diff.
+func render(ops []op, context int) string {
+	show := make([]bool, len(ops))
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		for d := -context; d <= context; d++ {
+			if k := i + d; k >= 0 && k < len(ops) {
+				show[k] = true
+			}
+		}
+	}
+
+	var out strings.Builder
+	skipping := false
+	for i, o := range ops {
+		if !show[i] {
+			if !skipping {
+				out.WriteString("...\n")
+				skipping = true
+			}
+			continue
+		}
+		skipping = false
+
+		switch o.kind {
+		case opDelete:
+			fmt.Fprintf(&out, "- %s\n", o.line)
+		case opInsert:
+			fmt.Fprintf(&out, "+ %s\n", o.line)
+		default:
+			fmt.Fprintf(&out, "  %s\n", o.line)
+		}
+	}
+
+	return out.String()
+}
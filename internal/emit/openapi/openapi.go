@@ -0,0 +1,299 @@
+// Package openapi converts parsed Novofon API documentation
+// (*models.APIData) directly into an OpenAPI 3.1 document: each method
+// becomes a path, RequestParams/ResponseParams become JSON Schemas,
+// RequestJSON/ResponseJSON become request/response examples, and
+// ErrorInfo.Errors become reusable entries under components.responses
+// referenced from each operation by $ref. This is the industry-standard
+// artifact the raw HTML doesn't provide.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xcono/novofon/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Document is an OpenAPI 3.1 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi" yaml:"openapi"`
+	Info       Info                `json:"info" yaml:"info"`
+	Paths      map[string]PathItem `json:"paths" yaml:"paths"`
+	Components Components          `json:"components" yaml:"components"`
+}
+
+// Info is an OpenAPI document's info section.
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+// PathItem maps an HTTP method (lowercase: "get", "post", ...) to the
+// Operation served at that method for one path.
+type PathItem map[string]*Operation
+
+// Operation is one method's OpenAPI operation.
+type Operation struct {
+	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	OperationID string              `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses" yaml:"responses"`
+}
+
+// RequestBody is an operation's request body.
+type RequestBody struct {
+	Required bool                 `json:"required" yaml:"required"`
+	Content  map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// Response is either an inline response or a $ref into
+// components.responses; Ref is set exclusively of the other fields.
+type Response struct {
+	Ref         string               `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string               `json:"description,omitempty" yaml:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// MediaType pairs a JSON Schema with an example value.
+type MediaType struct {
+	Schema  *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// Schema is a JSON Schema (2020-12), the object OpenAPI 3.1 embeds
+// directly rather than the constrained subset OpenAPI 3.0 used.
+type Schema struct {
+	Type        string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Enum        []string           `json:"enum,omitempty" yaml:"enum,omitempty"`
+}
+
+// Components holds objects shared across operations via $ref.
+type Components struct {
+	Responses map[string]Response `json:"responses,omitempty" yaml:"responses,omitempty"`
+}
+
+// FromMethod builds a single-method OpenAPI 3.1 Document from one parsed
+// page's APIData.
+func FromMethod(data *models.APIData) (*Document, error) {
+	if data == nil || data.MethodInfo == nil {
+		return nil, fmt.Errorf("invalid API data: method info is required")
+	}
+
+	doc := newDocument(data.MethodInfo.Title)
+	if err := addMethod(doc, data); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Merge builds one OpenAPI 3.1 Document covering every method in datas,
+// e.g. an entire directory scan's results. title names the merged
+// document (the generate package's GenerateBundle uses "Novofon API" by
+// convention).
+func Merge(title string, datas []*models.APIData) (*Document, error) {
+	doc := newDocument(title)
+	for _, data := range datas {
+		if data == nil || data.MethodInfo == nil {
+			continue
+		}
+		if err := addMethod(doc, data); err != nil {
+			return nil, fmt.Errorf("method %s: %w", data.MethodInfo.Name, err)
+		}
+	}
+	return doc, nil
+}
+
+func newDocument(title string) *Document {
+	return &Document{
+		OpenAPI:    "3.1.0",
+		Info:       Info{Title: title, Version: "1.0.0"},
+		Paths:      make(map[string]PathItem),
+		Components: Components{Responses: make(map[string]Response)},
+	}
+}
+
+// addMethod adds data's method as one operation to doc, registering any
+// new error mnemonics under doc.Components.Responses.
+func addMethod(doc *Document, data *models.APIData) error {
+	if data.MethodInfo == nil {
+		return fmt.Errorf("method info is required")
+	}
+
+	path := "/" + strings.ReplaceAll(data.MethodInfo.Name, ".", "/")
+	method := data.MethodInfo.HTTPMethod
+	if method == "" {
+		method = "post"
+	}
+
+	op := &Operation{
+		Summary:     data.MethodInfo.Title,
+		Description: data.MethodInfo.Description,
+		OperationID: data.MethodInfo.Name,
+		Responses:   make(map[string]Response),
+	}
+
+	if len(data.RequestParams) > 0 || data.RequestJSON != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				"application/json": {
+					Schema:  parametersSchema(data.RequestParams),
+					Example: data.RequestJSON,
+				},
+			},
+		}
+	}
+
+	op.Responses["200"] = Response{
+		Description: "Successful response",
+		Content: map[string]MediaType{
+			"application/json": {
+				Schema:  parametersSchema(data.ResponseParams),
+				Example: data.ResponseJSON,
+			},
+		},
+	}
+
+	if data.ErrorInfo != nil {
+		for _, errEntry := range data.ErrorInfo.Errors {
+			key := responseKey(errEntry)
+			if _, exists := doc.Components.Responses[key]; !exists {
+				doc.Components.Responses[key] = Response{
+					Description: errEntry.Description,
+					Content: map[string]MediaType{
+						"application/json": {
+							Schema: errorSchema(errEntry),
+						},
+					},
+				}
+			}
+			op.Responses[key] = Response{Ref: "#/components/responses/" + key}
+		}
+	}
+
+	if doc.Paths[path] == nil {
+		doc.Paths[path] = PathItem{}
+	}
+	doc.Paths[path][method] = op
+	return nil
+}
+
+// responseKey names data's error's entry under components.responses.
+// JSON-RPC error codes (e.g. "-32602") repeat across distinct errors, so
+// the mnemonic, which is unique per error, is used instead.
+func responseKey(err models.Error) string {
+	return err.Mnemonic
+}
+
+// parametersSchema builds an "object" Schema from a parsed parameter map,
+// with properties in alphabetical order for a stable, diffable document.
+func parametersSchema(params map[string]*models.Parameter) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var required []string
+	for _, name := range names {
+		param := params[name]
+		schema.Properties[name] = parameterSchema(param)
+		if param.Required {
+			required = append(required, name)
+		}
+	}
+	if len(required) > 0 {
+		schema.Required = required
+	}
+
+	return schema
+}
+
+// parameterSchema converts one Parameter into a JSON Schema, translating
+// its Type column and using AllowedValues (when set) as an enum.
+func parameterSchema(param *models.Parameter) *Schema {
+	schema := &Schema{
+		Type:        jsonSchemaType(param.Type),
+		Description: param.Description,
+	}
+
+	if param.AllowedValues != "" {
+		schema.Enum = splitAllowedValues(param.AllowedValues)
+	}
+
+	if schema.Type == "array" {
+		itemType := param.ArrayItemType
+		if itemType == "" {
+			itemType = "string"
+		}
+		schema.Items = &Schema{Type: jsonSchemaType(itemType)}
+	}
+
+	return schema
+}
+
+// jsonSchemaType maps a Parameter.Type value to a JSON Schema primitive
+// type, defaulting to "string" for anything unrecognized.
+func jsonSchemaType(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "number":
+		return "number"
+	case "integer", "int":
+		return "integer"
+	case "boolean", "bool":
+		return "boolean"
+	case "object":
+		return "object"
+	case "array":
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+// splitAllowedValues turns a Parameter.AllowedValues string like
+// "in, out" or "true, false" into its comma-separated values.
+func splitAllowedValues(raw string) []string {
+	parts := strings.Split(raw, ",")
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if v := strings.TrimSpace(part); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// errorSchema builds the JSON Schema for one JSON-RPC error's response
+// body.
+func errorSchema(err models.Error) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":        {Type: "string", Enum: []string{err.Code}},
+			"mnemonic":    {Type: "string", Enum: []string{err.Mnemonic}},
+			"description": {Type: "string"},
+		},
+		Required: []string{"code", "mnemonic"},
+	}
+}
+
+// ToJSON serializes doc as indented JSON.
+func (doc *Document) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// ToYAML serializes doc as YAML.
+func (doc *Document) ToYAML() ([]byte, error) {
+	return yaml.Marshal(doc)
+}
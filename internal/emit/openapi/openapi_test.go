@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+func testAPIData() *models.APIData {
+	return &models.APIData{
+		MethodInfo: &models.MethodInfo{
+			Name:        "test.method",
+			Title:       "Test Method",
+			Description: "A test method for validation",
+			HTTPMethod:  "post",
+		},
+		RequestParams: map[string]*models.Parameter{
+			"param1": {
+				Name:          "param1",
+				Type:          "string",
+				Required:      true,
+				Description:   "First parameter",
+				AllowedValues: "value1, value2",
+			},
+		},
+		ResponseParams: map[string]*models.Parameter{
+			"result": {
+				Name:        "result",
+				Type:        "string",
+				Required:    true,
+				Description: "Result value",
+			},
+		},
+		RequestJSON:  map[string]interface{}{"param1": "value1"},
+		ResponseJSON: map[string]interface{}{"result": "ok"},
+		ErrorInfo: &models.ErrorInfo{
+			Errors: []models.Error{
+				{Code: "-32602", Mnemonic: "invalid_params", Description: "Invalid parameters"},
+			},
+		},
+	}
+}
+
+func TestFromMethod(t *testing.T) {
+	doc, err := FromMethod(testAPIData())
+	if err != nil {
+		t.Fatalf("FromMethod returned error: %v", err)
+	}
+
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("expected OpenAPI 3.1.0, got %s", doc.OpenAPI)
+	}
+
+	op := doc.Paths["/test/method"]["post"]
+	if op == nil {
+		t.Fatal("expected a POST operation at /test/method")
+	}
+
+	param1 := op.RequestBody.Content["application/json"].Schema.Properties["param1"]
+	if param1.Type != "string" {
+		t.Errorf("expected param1 type string, got %s", param1.Type)
+	}
+	if len(param1.Enum) != 2 || param1.Enum[0] != "value1" || param1.Enum[1] != "value2" {
+		t.Errorf("expected param1 enum [value1 value2], got %v", param1.Enum)
+	}
+
+	resp, ok := op.Responses["invalid_params"]
+	if !ok {
+		t.Fatal("expected a response keyed by error mnemonic 'invalid_params'")
+	}
+	if resp.Ref != "#/components/responses/invalid_params" {
+		t.Errorf("expected $ref into components.responses, got %q", resp.Ref)
+	}
+	if _, ok := doc.Components.Responses["invalid_params"]; !ok {
+		t.Error("expected components.responses to hold the 'invalid_params' entry")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	second := testAPIData()
+	second.MethodInfo.Name = "test.other"
+
+	doc, err := Merge("Novofon API", []*models.APIData{testAPIData(), second})
+	if err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+
+	if len(doc.Paths) != 2 {
+		t.Errorf("expected 2 paths, got %d", len(doc.Paths))
+	}
+	if len(doc.Components.Responses) != 1 {
+		t.Errorf("expected the shared 'invalid_params' error deduplicated to 1 components.responses entry, got %d", len(doc.Components.Responses))
+	}
+}
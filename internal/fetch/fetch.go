@@ -0,0 +1,274 @@
+// Package fetch downloads the Novofon HTML documentation tree over HTTP so
+// main doesn't require a pre-downloaded directory to parse. It follows the
+// same index -> data_api/call_api -> method page structure getOutputFileName
+// expects, and keeps a .cache sidecar of ETag/Last-Modified headers so repeat
+// runs only re-download pages that actually changed.
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SourceMetadata describes one documentation source to fetch.
+type SourceMetadata struct {
+	// Name identifies the source in log output.
+	Name string
+	// DocURL is the base URL method pages are resolved against.
+	DocURL string
+	// IndexURL is the top-level index page listing data_api/call_api links.
+	IndexURL string
+	// LocalDir is the directory the fetched tree is materialized under.
+	LocalDir string
+	// Hacks are site-specific DOM cleanups applied to every fetched page
+	// before it's written to disk, so the parser never has to special-case
+	// a single source's quirks.
+	Hacks []func(*goquery.Document)
+}
+
+// cacheEntry records the conditional-GET validators for one fetched URL.
+type cacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// cacheFile is the .cache sidecar format, keyed by URL.
+type cacheFile map[string]cacheEntry
+
+// Fetcher downloads SourceMetadata trees with an *http.Client, reusing
+// conditional-GET validators across runs.
+type Fetcher struct {
+	Client *http.Client
+}
+
+// NewFetcher creates a Fetcher. A nil client falls back to
+// http.DefaultClient.
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Fetcher{Client: client}
+}
+
+// FetchAll materializes every source's documentation tree under its
+// LocalDir, returning the number of pages written (pages served as 304 Not
+// Modified don't count, since they were left untouched on disk).
+func (f *Fetcher) FetchAll(sources []SourceMetadata) (int, error) {
+	written := 0
+	for _, src := range sources {
+		n, err := f.fetchSource(src)
+		if err != nil {
+			return written, fmt.Errorf("fetch %s: %w", src.Name, err)
+		}
+		written += n
+	}
+	return written, nil
+}
+
+func (f *Fetcher) fetchSource(src SourceMetadata) (int, error) {
+	if err := os.MkdirAll(src.LocalDir, 0755); err != nil {
+		return 0, fmt.Errorf("create local dir: %w", err)
+	}
+
+	cachePath := filepath.Join(src.LocalDir, ".cache")
+	cache := loadCache(cachePath)
+
+	indexBody, _, err := f.get(src.IndexURL, cache)
+	if err != nil {
+		return 0, fmt.Errorf("fetch index: %w", err)
+	}
+
+	links, err := extractChildLinks(indexBody, src.IndexURL)
+	if err != nil {
+		return 0, fmt.Errorf("extract index links: %w", err)
+	}
+
+	written := 0
+	for _, link := range links {
+		changed, err := f.fetchPage(src, link, cache)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch %s: %v\n", link, err)
+			continue
+		}
+		if changed {
+			written++
+		}
+	}
+
+	if err := saveCache(cachePath, cache); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save cache for %s: %v\n", src.Name, err)
+	}
+
+	return written, nil
+}
+
+// fetchPage downloads a single method page, applies src.Hacks, and writes it
+// under src.LocalDir preserving the data_api/call_api/.../index.html shape.
+func (f *Fetcher) fetchPage(src SourceMetadata, pageURL string, cache cacheFile) (bool, error) {
+	body, notModified, err := f.get(pageURL, cache)
+	if err != nil {
+		return false, err
+	}
+	if notModified {
+		return false, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return false, fmt.Errorf("parse page: %w", err)
+	}
+	for _, hack := range src.Hacks {
+		hack(doc)
+	}
+	html, err := doc.Html()
+	if err != nil {
+		return false, fmt.Errorf("render page: %w", err)
+	}
+
+	localPath, err := localPathFor(src, pageURL)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return false, fmt.Errorf("create page dir: %w", err)
+	}
+	if err := os.WriteFile(localPath, []byte(html), 0644); err != nil {
+		return false, fmt.Errorf("write page: %w", err)
+	}
+
+	return true, nil
+}
+
+// localPathFor maps a fetched page's URL onto src.LocalDir, preserving the
+// data_api/call_api/<domain>/<method>/index.html structure getOutputFileName
+// expects.
+func localPathFor(src SourceMetadata, pageURL string) (string, error) {
+	base, err := url.Parse(src.DocURL)
+	if err != nil {
+		return "", fmt.Errorf("parse doc URL: %w", err)
+	}
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("parse page URL: %w", err)
+	}
+
+	rel := strings.TrimPrefix(parsed.Path, base.Path)
+	rel = strings.Trim(rel, "/")
+	if rel == "" {
+		rel = "index.html"
+	} else if !strings.HasSuffix(rel, ".html") {
+		rel = filepath.Join(rel, "index.html")
+	}
+
+	return filepath.Join(src.LocalDir, filepath.FromSlash(rel)), nil
+}
+
+// extractChildLinks finds every data_api/call_api method link on an index
+// page, returning them as absolute URLs.
+func extractChildLinks(indexHTML []byte, indexURL string) ([]string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(indexHTML)))
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []string
+	seen := make(map[string]bool)
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href, ok := s.Attr("href")
+		if !ok {
+			return
+		}
+		if !strings.Contains(href, "data_api") && !strings.Contains(href, "call_api") {
+			return
+		}
+		resolved, err := base.Parse(href)
+		if err != nil {
+			return
+		}
+		abs := resolved.String()
+		if seen[abs] {
+			return
+		}
+		seen[abs] = true
+		links = append(links, abs)
+	})
+
+	return links, nil
+}
+
+// get performs a conditional GET against rawURL using cache's stored
+// validators, returning (body, notModified, error). On success, cache is
+// updated with the response's new validators.
+func (f *Fetcher) get(rawURL string, cache cacheFile) ([]byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("build request: %w", err)
+	}
+
+	if entry, ok := cache[rawURL]; ok {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status %d for %s", resp.StatusCode, rawURL)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("read body: %w", err)
+	}
+
+	cache[rawURL] = cacheEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	return body, false, nil
+}
+
+func loadCache(path string) cacheFile {
+	cache := make(cacheFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(cacheFile)
+	}
+	return cache
+}
+
+func saveCache(path string, cache cacheFile) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cache: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,96 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFetcher_FetchAll(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/index.html":
+			w.Write([]byte(`<html><body><a href="/data_api/contact/get_contacts/index.html">get_contacts</a></body></html>`))
+		case "/data_api/contact/get_contacts/index.html":
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(`<html><body><h1>Get contacts</h1></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	sources := []SourceMetadata{{
+		Name:     "test",
+		DocURL:   server.URL + "/",
+		IndexURL: server.URL + "/index.html",
+		LocalDir: dir,
+	}}
+
+	f := NewFetcher(server.Client())
+	written, err := f.FetchAll(sources)
+	if err != nil {
+		t.Fatalf("FetchAll failed: %v", err)
+	}
+	if written != 1 {
+		t.Errorf("Expected 1 written page, got %d", written)
+	}
+
+	pagePath := filepath.Join(dir, "data_api", "contact", "get_contacts", "index.html")
+	if _, err := os.Stat(pagePath); err != nil {
+		t.Errorf("Expected page to be written at %s: %v", pagePath, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".cache")); err != nil {
+		t.Errorf("Expected .cache sidecar to be written: %v", err)
+	}
+}
+
+func TestFetcher_FetchAll_ConditionalGetSkipsUnchanged(t *testing.T) {
+	pageHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.html":
+			w.Write([]byte(`<html><body><a href="/data_api/contact/get_contacts/index.html">get_contacts</a></body></html>`))
+		case "/data_api/contact/get_contacts/index.html":
+			pageHits++
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(`<html><body><h1>Get contacts</h1></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	sources := []SourceMetadata{{
+		Name:     "test",
+		DocURL:   server.URL + "/",
+		IndexURL: server.URL + "/index.html",
+		LocalDir: dir,
+	}}
+
+	f := NewFetcher(server.Client())
+	if _, err := f.FetchAll(sources); err != nil {
+		t.Fatalf("first FetchAll failed: %v", err)
+	}
+	written, err := f.FetchAll(sources)
+	if err != nil {
+		t.Fatalf("second FetchAll failed: %v", err)
+	}
+	if written != 0 {
+		t.Errorf("Expected the second fetch to skip the unchanged page, got %d written", written)
+	}
+	if pageHits != 2 {
+		t.Errorf("Expected the page to be requested twice (conditionally), got %d", pageHits)
+	}
+}
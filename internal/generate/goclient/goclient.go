@@ -0,0 +1,331 @@
+// Package goclient generates an idiomatic, typed Go client from a parsed
+// OpenAPISpec, following the same "walk the schema tree, synthesize Go type
+// names from operation names and property paths" approach used by Google's
+// google-api-go-generator against discovery documents.
+package goclient
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xcono/novofon/internal/generate"
+)
+
+// Generator emits Go source for a typed client wrapping Novofon's JSON-RPC
+// methods.
+type Generator struct {
+	// PackageName is the package clause of the generated file.
+	PackageName string
+}
+
+// NewGenerator creates a Go client generator for the given package name.
+func NewGenerator(packageName string) *Generator {
+	if packageName == "" {
+		packageName = "novofon"
+	}
+	return &Generator{PackageName: packageName}
+}
+
+// Generate renders Go source implementing one Client method per path in
+// spec, with typed request/response structs derived from the params and
+// result.data schemas, and an APIError carrying sentinel values for the
+// codes listed in spec.XErrors.
+func (g *Generator) Generate(spec *generate.OpenAPISpec) ([]byte, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil spec")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by generate/goclient. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.PackageName)
+	b.WriteString("import (\n\t\"bytes\"\n\t\"context\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"net/http\"\n\t\"sync/atomic\"\n)\n\n")
+
+	g.writeClient(&b)
+	g.writeErrors(&b, spec)
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		op := operationFor(spec.Paths[path])
+		if op == nil {
+			continue
+		}
+		g.writeOperation(&b, path, op)
+	}
+
+	return []byte(b.String()), nil
+}
+
+// operationFor returns whichever HTTP verb is populated on a PathItem; the
+// generator treats JSON-RPC methods as uniformly POST-shaped regardless.
+func operationFor(item generate.PathItem) *generate.Operation {
+	switch {
+	case item.Post != nil:
+		return item.Post
+	case item.Get != nil:
+		return item.Get
+	case item.Put != nil:
+		return item.Put
+	case item.Delete != nil:
+		return item.Delete
+	}
+	return nil
+}
+
+func (g *Generator) writeClient(b *strings.Builder) {
+	b.WriteString(`// Client is a typed JSON-RPC client for the Novofon API.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	nextID     int64
+}
+
+// NewClient creates a Client posting JSON-RPC requests to baseURL. A nil
+// httpClient falls back to http.DefaultClient.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{HTTPClient: httpClient, BaseURL: baseURL}
+}
+
+type jsonrpcRequest struct {
+	JSONRPC string      ` + "`json:\"jsonrpc\"`" + `
+	ID      int64       ` + "`json:\"id\"`" + `
+	Method  string      ` + "`json:\"method\"`" + `
+	Params  interface{} ` + "`json:\"params\"`" + `
+}
+
+type jsonrpcResponse struct {
+	JSONRPC string          ` + "`json:\"jsonrpc\"`" + `
+	ID      int64           ` + "`json:\"id\"`" + `
+	Result  json.RawMessage ` + "`json:\"result,omitempty\"`" + `
+	Error   *APIError       ` + "`json:\"error,omitempty\"`" + `
+}
+
+// do builds the JSON-RPC envelope for method, posts it, and decodes the
+// result into out (which should be a pointer), returning the typed APIError
+// when the server reports one.
+func (c *Client) do(ctx context.Context, method string, params interface{}, out interface{}) error {
+	id := atomic.AddInt64(&c.nextID, 1)
+
+	body, err := json.Marshal(jsonrpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp jsonrpcResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	if out == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, out)
+}
+
+`)
+}
+
+func (g *Generator) writeErrors(b *strings.Builder, spec *generate.OpenAPISpec) {
+	b.WriteString(`// APIError is a JSON-RPC error as returned by the Novofon API.
+type APIError struct {
+	Code     int    ` + "`json:\"code\"`" + `
+	Mnemonic string ` + "`json:\"mnemonic,omitempty\"`" + `
+	Message  string ` + "`json:\"message\"`" + `
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("novofon: %s (code %d, %s)", e.Message, e.Code, e.Mnemonic)
+}
+
+// Is supports errors.Is(err, ErrXxx) by comparing mnemonics.
+func (e *APIError) Is(target error) bool {
+	other, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return e.Mnemonic == other.Mnemonic
+}
+
+`)
+
+	if spec.XErrors == nil || len(spec.XErrors.Errors) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range spec.XErrors.Errors {
+		if e.Mnemonic == "" || seen[e.Mnemonic] {
+			continue
+		}
+		seen[e.Mnemonic] = true
+		fmt.Fprintf(b, "// Err%s is returned when the API reports mnemonic %q.\nvar Err%s = &APIError{Mnemonic: %q, Message: %q}\n\n",
+			goName(e.Mnemonic), e.Mnemonic, goName(e.Mnemonic), e.Mnemonic, e.Description)
+	}
+}
+
+func (g *Generator) writeOperation(b *strings.Builder, path string, op *generate.Operation) {
+	opName := goName(strings.TrimPrefix(path, "/"))
+	methodName := strings.TrimPrefix(path, "/")
+
+	reqType := opName + "Request"
+	respType := opName + "Response"
+
+	if op.RequestBody != nil {
+		if mt, ok := op.RequestBody.Content["application/json"]; ok {
+			if params, ok := mt.Schema.Properties["params"]; ok {
+				writeStruct(b, reqType, params)
+			} else {
+				writeStruct(b, reqType, mt.Schema)
+			}
+		}
+	}
+	if !hasType(b, reqType) {
+		fmt.Fprintf(b, "// %s carries the parameters for %s.\ntype %s struct{}\n\n", reqType, methodName, reqType)
+	}
+
+	if resp, ok := op.Responses["200"]; ok {
+		if mt, ok := resp.Content["application/json"]; ok {
+			if result, ok := mt.Schema.Properties["result"]; ok {
+				if data, ok := result.Properties["data"]; ok {
+					writeStruct(b, respType, data)
+				}
+			}
+		}
+	}
+	if !hasType(b, respType) {
+		fmt.Fprintf(b, "// %s carries the result of %s.\ntype %s struct{}\n\n", respType, methodName, respType)
+	}
+
+	fmt.Fprintf(b, "// %s calls the %q JSON-RPC method.\nfunc (c *Client) %s(ctx context.Context, req %s) (*%s, error) {\n",
+		opName, methodName, opName, reqType, respType)
+	fmt.Fprintf(b, "\tvar resp %s\n", respType)
+	fmt.Fprintf(b, "\tif err := c.do(ctx, %q, req, &resp); err != nil {\n\t\treturn nil, err\n\t}\n", methodName)
+	b.WriteString("\treturn &resp, nil\n}\n\n")
+}
+
+// hasType is a cheap guard against emitting a struct twice when a schema
+// couldn't be resolved above; it just checks whether the builder already
+// contains the type declaration.
+func hasType(b *strings.Builder, typeName string) bool {
+	return strings.Contains(b.String(), "type "+typeName+" struct")
+}
+
+// writeStruct emits a Go struct for an object schema: required fields are
+// non-pointer, optional fields are pointers, and string enums become a named
+// type with constants.
+func writeStruct(b *strings.Builder, typeName string, schema generate.Schema) {
+	required := make(map[string]bool, len(schema.Required))
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(b, "// %s is generated from its OpenAPI schema.\ntype %s struct {\n", typeName, typeName)
+	var enumProps []string
+	for _, name := range names {
+		prop := schema.Properties[name]
+		fieldName := goName(name)
+		goType := goType(prop)
+		if prop.Type == "string" && len(prop.Enum) > 0 {
+			goType = typeName + fieldName + "Value"
+			enumProps = append(enumProps, name)
+		}
+		tag := name
+		if !required[name] {
+			goType = "*" + goType
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:\"%s\"`\n", fieldName, goType, tag)
+	}
+	b.WriteString("}\n\n")
+
+	for _, name := range enumProps {
+		writeEnum(b, typeName+goName(name), schema.Properties[name].Enum)
+	}
+	if len(schema.Enum) > 0 && schema.Type == "string" {
+		writeEnum(b, typeName, schema.Enum)
+	}
+}
+
+func writeEnum(b *strings.Builder, owner string, values []interface{}) {
+	enumType := owner + "Value"
+	fmt.Fprintf(b, "// %s enumerates the allowed values for %s.\ntype %s string\n\nconst (\n", enumType, owner, enumType)
+	for _, v := range values {
+		fmt.Fprintf(b, "\t%s%s %s = %q\n", enumType, goName(fmt.Sprintf("%v", v)), enumType, v)
+	}
+	b.WriteString(")\n\n")
+}
+
+// goType maps a Schema to a Go type name; unresolved nested objects fall
+// back to map[string]interface{} rather than generating an unbounded number
+// of anonymous nested structs.
+func goType(s generate.Schema) string {
+	if s.Ref != "" {
+		return "interface{}"
+	}
+	switch s.Type {
+	case "string":
+		return "string"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items != nil {
+			return "[]" + goType(*s.Items)
+		}
+		return "[]interface{}"
+	case "object":
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+}
+
+// goName converts a snake/dot-separated identifier into PascalCase.
+func goName(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || r == ' '
+	})
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]))
+		b.WriteString(p[1:])
+	}
+	if b.Len() == 0 {
+		return "Field"
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package goclient
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcono/novofon/internal/generate"
+	"github.com/xcono/novofon/internal/models"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	gen := generate.NewOpenAPIGenerator()
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "start.simple_call", Title: "Start simple call", HTTPMethod: "post"},
+		RequestParams: map[string]*models.Parameter{
+			"contact": {Name: "contact", Type: "string", Required: true},
+		},
+		ResponseParams: map[string]*models.Parameter{
+			"call_session_id": {Name: "call_session_id", Type: "number", Required: true},
+		},
+		ErrorInfo: &models.ErrorInfo{
+			Errors: []models.Error{{Code: "-32602", Mnemonic: "tts_text_exceeded", Description: "Text too long"}},
+		},
+	}
+
+	spec, err := gen.GenerateSpec(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSpec failed: %v", err)
+	}
+
+	src, err := NewGenerator("novofon").Generate(spec)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	out := string(src)
+	for _, want := range []string{
+		"package novofon",
+		"type StartSimpleCallRequest struct",
+		"type StartSimpleCallResponse struct",
+		"func (c *Client) StartSimpleCall(ctx context.Context",
+		"ErrTtsTextExceeded",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected generated source to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerator_Generate_NilSpec(t *testing.T) {
+	if _, err := NewGenerator("novofon").Generate(nil); err == nil {
+		t.Error("Expected error for nil spec")
+	}
+}
@@ -1,8 +1,11 @@
 package generate
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/xcono/novofon/internal/models"
@@ -11,10 +14,16 @@ import (
 
 // OpenAPISpec represents an OpenAPI 3.0 specification
 type OpenAPISpec struct {
-	OpenAPI string              `yaml:"openapi"`
-	Info    OpenAPIInfo         `yaml:"info"`
-	Paths   map[string]PathItem `yaml:"paths"`
-	XErrors *models.ErrorInfo   `yaml:"x-errors,omitempty"`
+	OpenAPI    string              `yaml:"openapi"`
+	Info       OpenAPIInfo         `yaml:"info"`
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components *Components         `yaml:"components,omitempty"`
+	XErrors    *models.ErrorInfo   `yaml:"x-errors,omitempty"`
+}
+
+// Components holds reusable objects referenced via $ref elsewhere in the spec
+type Components struct {
+	Schemas map[string]Schema `yaml:"schemas,omitempty"`
 }
 
 // OpenAPIInfo represents the info section of OpenAPI spec
@@ -36,9 +45,27 @@ type PathItem struct {
 type Operation struct {
 	Summary     string              `yaml:"summary"`
 	Description string              `yaml:"description"`
+	Parameters  []OpenAPIParameter  `yaml:"parameters,omitempty"`
 	RequestBody *RequestBody        `yaml:"requestBody,omitempty"`
 	Responses   map[string]Response `yaml:"responses"`
 	Tags        []string            `yaml:"tags,omitempty"`
+
+	// XVersion and XStability mirror models.MethodInfo.Version/Stability so
+	// the compile package can resolve which version of an operation wins a
+	// given pin without re-parsing HTML. XSource is stamped later, by that
+	// resolver, with the path of the file the winning operation came from.
+	XVersion   string `yaml:"x-novofon-version,omitempty"`
+	XStability string `yaml:"x-novofon-stability,omitempty"`
+	XSource    string `yaml:"x-novofon-source,omitempty"`
+}
+
+// OpenAPIParameter represents a query/path/header parameter, for Novofon
+// parameters whose models.Parameter.In isn't "body".
+type OpenAPIParameter struct {
+	Name     string `yaml:"name"`
+	In       string `yaml:"in"`
+	Required bool   `yaml:"required"`
+	Schema   Schema `yaml:"schema"`
 }
 
 // RequestBody represents a request body in OpenAPI spec
@@ -74,15 +101,55 @@ type Schema struct {
 	Items       *Schema           `yaml:"items,omitempty"`
 	XFiltering  string            `yaml:"x-filtering,omitempty"`
 	XSorting    string            `yaml:"x-sorting,omitempty"`
+	Ref         string            `yaml:"$ref,omitempty"`
+
+	// Nullable marks a property as accepting null. Under 3.0 it marshals as
+	// the `nullable` flag; under 3.1 it's folded into a `type` array instead.
+	Nullable bool `yaml:"-" json:"-"`
+
+	// Examples holds the 3.1 plural form of Example. When set it takes
+	// precedence over Example during 3.1 marshaling.
+	Examples []interface{} `yaml:"-" json:"-"`
+
+	ExclusiveMinimum *float64 `yaml:"-" json:"-"`
+	ExclusiveMaximum *float64 `yaml:"-" json:"-"`
+
+	// version is set by the generator during emission and controls whether
+	// marshalMap renders OAS 3.0 or 3.1 / JSON Schema 2020-12 conventions.
+	// It is never itself serialized.
+	version string
 }
 
-// OpenAPIGenerator generates OpenAPI 3.0 specifications from parsed API data
+const (
+	// VersionOAS30 targets OpenAPI 3.0 output (the default).
+	VersionOAS30 = "3.0.0"
+	// VersionOAS31 targets OpenAPI 3.1 / JSON Schema 2020-12 output.
+	VersionOAS31 = "3.1.0"
+)
+
+// Options configures an OpenAPIGenerator.
+type Options struct {
+	// Version selects the output OpenAPI version: VersionOAS30 (default) or
+	// VersionOAS31.
+	Version string
+}
+
+// OpenAPIGenerator generates OpenAPI specifications from parsed API data
 type OpenAPIGenerator struct {
 	supportedTypes map[string]string
+	version        string
 }
 
-// NewOpenAPIGenerator creates a new OpenAPI generator
-func NewOpenAPIGenerator() *OpenAPIGenerator {
+// NewOpenAPIGenerator creates a new OpenAPI generator. With no options it
+// targets OpenAPI 3.0; pass Options{Version: VersionOAS31} for 3.1 output.
+func NewOpenAPIGenerator(opts ...Options) *OpenAPIGenerator {
+	version := VersionOAS30
+	for _, opt := range opts {
+		if opt.Version != "" {
+			version = opt.Version
+		}
+	}
+
 	return &OpenAPIGenerator{
 		supportedTypes: map[string]string{
 			"string":  "string",
@@ -92,9 +159,139 @@ func NewOpenAPIGenerator() *OpenAPIGenerator {
 			"array":   "array",
 			"enum":    "string",
 		},
+		version: version,
 	}
 }
 
+// MarshalYAML renders the schema according to its target OpenAPI version.
+func (s Schema) MarshalYAML() (interface{}, error) {
+	return s.marshalMap(), nil
+}
+
+// MarshalJSON renders the schema according to its target OpenAPI version.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.marshalMap())
+}
+
+// marshalMap builds the wire representation of the schema, branching on
+// version between OAS 3.0 (nullable flag, singular example) and 3.1 / JSON
+// Schema 2020-12 (type arrays for nullability, plural examples, const for
+// single-value enums).
+func (s Schema) marshalMap() map[string]interface{} {
+	m := make(map[string]interface{})
+
+	if s.Ref != "" {
+		m["$ref"] = s.Ref
+		return m
+	}
+
+	is31 := s.version == VersionOAS31
+
+	if is31 {
+		if s.Type != "" {
+			if s.Nullable {
+				m["type"] = []string{s.Type, "null"}
+			} else {
+				m["type"] = s.Type
+			}
+		}
+		if len(s.Enum) == 1 {
+			m["const"] = s.Enum[0]
+		} else if len(s.Enum) > 1 {
+			m["enum"] = s.Enum
+		}
+		switch {
+		case len(s.Examples) > 0:
+			m["examples"] = s.Examples
+		case s.Example != nil:
+			m["examples"] = []interface{}{s.Example}
+		}
+		if s.ExclusiveMinimum != nil {
+			m["exclusiveMinimum"] = *s.ExclusiveMinimum
+		}
+		if s.ExclusiveMaximum != nil {
+			m["exclusiveMaximum"] = *s.ExclusiveMaximum
+		}
+	} else {
+		if s.Type != "" {
+			m["type"] = s.Type
+		}
+		if s.Nullable {
+			m["nullable"] = true
+		}
+		if len(s.Enum) > 0 {
+			m["enum"] = s.Enum
+		}
+		if s.Example != nil {
+			m["example"] = s.Example
+		}
+	}
+
+	if s.Description != "" {
+		m["description"] = s.Description
+	}
+	if len(s.Properties) > 0 {
+		props := make(map[string]interface{}, len(s.Properties))
+		for k, p := range s.Properties {
+			props[k] = p
+		}
+		m["properties"] = props
+	}
+	if len(s.Required) > 0 {
+		m["required"] = s.Required
+	}
+	if s.Format != "" {
+		m["format"] = s.Format
+	}
+	if s.MaxLength != nil {
+		m["maxLength"] = *s.MaxLength
+	}
+	if s.MinLength != nil {
+		m["minLength"] = *s.MinLength
+	}
+	if s.Maximum != nil {
+		m["maximum"] = *s.Maximum
+	}
+	if s.Minimum != nil {
+		m["minimum"] = *s.Minimum
+	}
+	if s.Items != nil {
+		m["items"] = *s.Items
+	}
+	if s.XFiltering != "" {
+		m["x-filtering"] = s.XFiltering
+	}
+	if s.XSorting != "" {
+		m["x-sorting"] = s.XSorting
+	}
+
+	return m
+}
+
+// withVersion stamps a schema tree (properties, items) with the generator's
+// target version so marshalMap renders the right dialect.
+func withVersion(s Schema, version string) Schema {
+	s.version = version
+	if s.Properties != nil {
+		props := make(map[string]Schema, len(s.Properties))
+		for k, p := range s.Properties {
+			props[k] = withVersion(p, version)
+		}
+		s.Properties = props
+	}
+	if s.Items != nil {
+		item := withVersion(*s.Items, version)
+		s.Items = &item
+	}
+	return s
+}
+
+// looksNullable reports whether a Russian-language description hints the
+// value may be null (e.g. "может быть null").
+func looksNullable(description string) bool {
+	return strings.Contains(strings.ToLower(description), "может быть null")
+}
+
 // GenerateSpec generates an OpenAPI 3.0 specification from parsed API data
 func (g *OpenAPIGenerator) GenerateSpec(apiData *models.APIData) (*OpenAPISpec, error) {
 	if apiData == nil || apiData.MethodInfo == nil {
@@ -113,7 +310,7 @@ func (g *OpenAPIGenerator) GenerateSpec(apiData *models.APIData) (*OpenAPISpec,
 	}
 
 	spec := &OpenAPISpec{
-		OpenAPI: "3.0.0",
+		OpenAPI: g.version,
 		Info: OpenAPIInfo{
 			Title:       title,
 			Version:     "1.0.0",
@@ -147,9 +344,267 @@ func (g *OpenAPIGenerator) GenerateSpec(apiData *models.APIData) (*OpenAPISpec,
 
 	spec.Paths[path] = pathItem
 
+	rewriteOperations(spec, func(s Schema) Schema { return withVersion(s, g.version) })
+
 	return spec, nil
 }
 
+// GenerateBundle generates a single OpenAPI document covering every method in
+// apis. Unlike GenerateSpec, which inlines every parameter schema, repeated
+// object/enum schemas (the JSON-RPC envelope, pagination-style wrappers,
+// shared parameter groups, ...) are promoted to components.schemas and
+// referenced via $ref so the bundle stays reviewable at hundreds of methods.
+func (g *OpenAPIGenerator) GenerateBundle(apis []*models.APIData) (*OpenAPISpec, error) {
+	if len(apis) == 0 {
+		return nil, fmt.Errorf("no API data provided")
+	}
+
+	bundle := &OpenAPISpec{
+		OpenAPI: g.version,
+		Info: OpenAPIInfo{
+			Title:       "Novofon API",
+			Version:     "1.0.0",
+			Description: "Combined Novofon API specification",
+		},
+		Paths: make(map[string]PathItem),
+	}
+
+	var allErrors []models.Error
+	seenErrors := make(map[string]bool)
+
+	for _, apiData := range apis {
+		if apiData == nil || apiData.MethodInfo == nil {
+			continue
+		}
+
+		path := fmt.Sprintf("/%s", apiData.MethodInfo.Name)
+		operation := g.generateOperation(apiData)
+
+		pathItem := PathItem{}
+		switch strings.ToLower(apiData.MethodInfo.HTTPMethod) {
+		case "get":
+			pathItem.Get = operation
+		case "put":
+			pathItem.Put = operation
+		case "delete":
+			pathItem.Delete = operation
+		default:
+			pathItem.Post = operation
+		}
+		bundle.Paths[path] = pathItem
+
+		if apiData.ErrorInfo != nil {
+			for _, e := range apiData.ErrorInfo.Errors {
+				key := e.Code + "|" + e.Mnemonic
+				if !seenErrors[key] {
+					seenErrors[key] = true
+					allErrors = append(allErrors, e)
+				}
+			}
+		}
+	}
+
+	if len(allErrors) > 0 {
+		bundle.XErrors = &models.ErrorInfo{Errors: allErrors}
+	}
+
+	g.extractComponents(bundle)
+
+	rewriteOperations(bundle, func(s Schema) Schema { return withVersion(s, g.version) })
+	if bundle.Components != nil {
+		for name, s := range bundle.Components.Schemas {
+			bundle.Components.Schemas[name] = withVersion(s, g.version)
+		}
+	}
+
+	return bundle, nil
+}
+
+// extractComponents walks every operation's schemas, fingerprints each
+// object or enum schema with schemaFingerprint, and promotes any schema
+// occurring more than once into bundle.Components.Schemas, rewriting the
+// original location to a $ref. This mirrors kin-openapi's internalize_refs
+// approach of deduplicating repeated structures after the fact rather than
+// requiring the generator to know about sharing up front.
+func (g *OpenAPIGenerator) extractComponents(spec *OpenAPISpec) {
+	counts := make(map[string]int)
+	var count func(s Schema)
+	count = func(s Schema) {
+		if len(s.Properties) > 0 || len(s.Enum) > 0 {
+			counts[schemaFingerprint(s)]++
+		}
+		for _, p := range s.Properties {
+			count(p)
+		}
+		if s.Items != nil {
+			count(*s.Items)
+		}
+	}
+
+	walkOperations(spec, func(s Schema) {
+		count(s)
+	})
+
+	components := make(map[string]Schema)
+	names := make(map[string]string)
+	nameCounter := 0
+
+	var rewrite func(s Schema) Schema
+	rewrite = func(s Schema) Schema {
+		// Fingerprint before descending into children: counts was built
+		// from the same untouched, pre-substitution tree, so the
+		// promotion decision below has to use that same original shape
+		// rather than one with already-$ref'd children, or a promoted
+		// parent's fingerprint would never match its entry in counts.
+		fp := schemaFingerprint(s)
+		promote := (len(s.Properties) > 0 || len(s.Enum) > 0) && counts[fp] > 1
+
+		if len(s.Properties) > 0 {
+			newProps := make(map[string]Schema, len(s.Properties))
+			for k, p := range s.Properties {
+				newProps[k] = rewrite(p)
+			}
+			s.Properties = newProps
+		}
+		if s.Items != nil {
+			newItem := rewrite(*s.Items)
+			s.Items = &newItem
+		}
+
+		if !promote {
+			return s
+		}
+
+		name, ok := names[fp]
+		if !ok {
+			nameCounter++
+			name = componentName(s, nameCounter)
+			if _, taken := components[name]; taken {
+				name = fmt.Sprintf("%s%d", name, nameCounter)
+			}
+			names[fp] = name
+			components[name] = s
+		}
+		return Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	rewriteOperations(spec, rewrite)
+
+	if len(components) > 0 {
+		spec.Components = &Components{Schemas: components}
+	}
+}
+
+// walkOperations visits every request/response schema across all paths.
+func walkOperations(spec *OpenAPISpec, visit func(Schema)) {
+	for _, pathItem := range spec.Paths {
+		for _, op := range []*Operation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete} {
+			if op == nil {
+				continue
+			}
+			for _, p := range op.Parameters {
+				visit(p.Schema)
+			}
+			if op.RequestBody != nil {
+				for _, mt := range op.RequestBody.Content {
+					visit(mt.Schema)
+				}
+			}
+			for _, resp := range op.Responses {
+				for _, mt := range resp.Content {
+					visit(mt.Schema)
+				}
+			}
+		}
+	}
+}
+
+// rewriteOperations replaces every request/response schema in place with the
+// result of applying rewrite, used to swap inlined schemas for $ref entries.
+func rewriteOperations(spec *OpenAPISpec, rewrite func(Schema) Schema) {
+	for _, pathItem := range spec.Paths {
+		for _, op := range []*Operation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete} {
+			if op == nil {
+				continue
+			}
+			for i, p := range op.Parameters {
+				op.Parameters[i].Schema = rewrite(p.Schema)
+			}
+			if op.RequestBody != nil {
+				for ct, mt := range op.RequestBody.Content {
+					mt.Schema = rewrite(mt.Schema)
+					op.RequestBody.Content[ct] = mt
+				}
+			}
+			for code, resp := range op.Responses {
+				for ct, mt := range resp.Content {
+					mt.Schema = rewrite(mt.Schema)
+					resp.Content[ct] = mt
+				}
+				op.Responses[code] = resp
+			}
+		}
+	}
+}
+
+// componentName picks a readable name for a promoted schema, falling back to
+// a generic counter-based name when nothing recognizable matches.
+func componentName(s Schema, n int) string {
+	if _, hasData := s.Properties["data"]; hasData {
+		if _, hasMetadata := s.Properties["metadata"]; hasMetadata {
+			return "ResultEnvelope"
+		}
+	}
+	if _, hasJSONRPC := s.Properties["jsonrpc"]; hasJSONRPC {
+		if _, hasError := s.Properties["error"]; hasError {
+			return "ErrorEnvelope"
+		}
+		return "JSONRPCEnvelope"
+	}
+	if _, hasCode := s.Properties["code"]; hasCode {
+		if _, hasMessage := s.Properties["message"]; hasMessage {
+			return "ErrorObject"
+		}
+	}
+	return fmt.Sprintf("Schema%d", n)
+}
+
+// schemaFingerprint canonicalizes a schema (sorted properties, normalized
+// descriptions/examples) into a stable hash so structurally identical
+// schemas collapse to the same component regardless of textual differences.
+func schemaFingerprint(s Schema) string {
+	canonical := canonicalizeSchema(s)
+	data, _ := json.Marshal(canonical)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalizeSchema strips fields that shouldn't affect schema identity
+// (free-text descriptions, examples) and sorts Required so two schemas with
+// the same shape but different prose fingerprint identically.
+func canonicalizeSchema(s Schema) Schema {
+	c := s
+	c.Description = ""
+	c.Example = nil
+	if len(c.Required) > 0 {
+		required := append([]string(nil), c.Required...)
+		sort.Strings(required)
+		c.Required = required
+	}
+	if c.Properties != nil {
+		props := make(map[string]Schema, len(c.Properties))
+		for k, p := range c.Properties {
+			props[k] = canonicalizeSchema(p)
+		}
+		c.Properties = props
+	}
+	if c.Items != nil {
+		items := canonicalizeSchema(*c.Items)
+		c.Items = &items
+	}
+	return c
+}
+
 // cleanText removes unwanted whitespace and newlines from text content
 func cleanText(text string) string {
 	// Remove all types of newlines and excessive whitespace
@@ -175,16 +630,58 @@ func (g *OpenAPIGenerator) generateOperation(apiData *models.APIData) *Operation
 		Description: g.generateDescription(apiData),
 		Responses:   g.generateResponses(apiData),
 		Tags:        []string{"novofon"},
+		XVersion:    methodInfo.Version,
+		XStability:  methodInfo.Stability,
 	}
 
-	// Add request body if there are request parameters
-	if len(apiData.RequestParams) > 0 {
-		operation.RequestBody = g.generateRequestBody(apiData)
+	// Partition request params by location: query/path/header params become
+	// proper OpenAPI `parameters:` entries, everything else (the default,
+	// "body") continues through the JSON-RPC request body.
+	bodyParams, otherParams := partitionParamsByLocation(apiData.RequestParams)
+
+	for _, name := range sortedParamNames(otherParams) {
+		param := otherParams[name]
+		operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+			Name:     name,
+			In:       param.In,
+			Required: param.Required,
+			Schema:   g.generateParameterSchema(param),
+		})
+	}
+
+	if len(bodyParams) > 0 {
+		operation.RequestBody = g.generateRequestBody(apiData.MethodInfo.Name, bodyParams)
 	}
 
 	return operation
 }
 
+// partitionParamsByLocation splits params into body params (the default, and
+// anything explicitly marked "body") and non-body params (query/path/header).
+func partitionParamsByLocation(params map[string]*models.Parameter) (body, other map[string]*models.Parameter) {
+	body = make(map[string]*models.Parameter)
+	other = make(map[string]*models.Parameter)
+	for name, param := range params {
+		if param.In != "" && param.In != "body" {
+			other[name] = param
+			continue
+		}
+		body[name] = param
+	}
+	return body, other
+}
+
+// sortedParamNames returns the keys of params in sorted order, so generated
+// parameter lists are deterministic.
+func sortedParamNames(params map[string]*models.Parameter) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // generateDescription generates a detailed description for the operation
 func (g *OpenAPIGenerator) generateDescription(apiData *models.APIData) string {
 	var parts []string
@@ -215,7 +712,7 @@ func (g *OpenAPIGenerator) generateDescription(apiData *models.APIData) string {
 }
 
 // generateRequestBody generates a request body schema
-func (g *OpenAPIGenerator) generateRequestBody(apiData *models.APIData) *RequestBody {
+func (g *OpenAPIGenerator) generateRequestBody(methodName string, bodyParams map[string]*models.Parameter) *RequestBody {
 	properties := make(map[string]Schema)
 	var required []string
 
@@ -236,7 +733,7 @@ func (g *OpenAPIGenerator) generateRequestBody(apiData *models.APIData) *Request
 	properties["method"] = Schema{
 		Type:        "string",
 		Description: "Method name",
-		Example:     apiData.MethodInfo.Name,
+		Example:     methodName,
 	}
 	required = append(required, "method")
 
@@ -244,7 +741,7 @@ func (g *OpenAPIGenerator) generateRequestBody(apiData *models.APIData) *Request
 	paramsProperties := make(map[string]Schema)
 	var paramsRequired []string
 
-	for name, param := range apiData.RequestParams {
+	for name, param := range bodyParams {
 		schema := g.generateParameterSchema(param)
 		paramsProperties[name] = schema
 		if param.Required {
@@ -419,6 +916,7 @@ func (g *OpenAPIGenerator) generateParameterSchema(param *models.Parameter) Sche
 	schema := Schema{
 		Type:        g.supportedTypes[param.Type],
 		Description: cleanText(param.Description),
+		Nullable:    looksNullable(param.Description),
 	}
 
 	// Handle array types - add items schema
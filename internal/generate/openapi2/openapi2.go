@@ -0,0 +1,211 @@
+// Package openapi2 converts a generated OpenAPI 3.0 OpenAPISpec down to a
+// Swagger 2.0 (OpenAPI 2.0) document, for consumers that haven't migrated
+// off the older spec version yet.
+package openapi2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/xcono/novofon/internal/generate"
+	"gopkg.in/yaml.v3"
+)
+
+// Swagger2Spec is a Swagger 2.0 document.
+type Swagger2Spec struct {
+	Swagger     string                    `yaml:"swagger"`
+	Info        Swagger2Info              `yaml:"info"`
+	Host        string                    `yaml:"host,omitempty"`
+	BasePath    string                    `yaml:"basePath,omitempty"`
+	Schemes     []string                  `yaml:"schemes,omitempty"`
+	Paths       map[string]Swagger2Path   `yaml:"paths"`
+	Definitions map[string]Swagger2Schema `yaml:"definitions,omitempty"`
+}
+
+// Swagger2Info mirrors OpenAPIInfo for a Swagger 2.0 document.
+type Swagger2Info struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// Swagger2Path holds the operations for a single path.
+type Swagger2Path struct {
+	Post   *Swagger2Operation `yaml:"post,omitempty"`
+	Get    *Swagger2Operation `yaml:"get,omitempty"`
+	Put    *Swagger2Operation `yaml:"put,omitempty"`
+	Delete *Swagger2Operation `yaml:"delete,omitempty"`
+}
+
+// Swagger2Operation is a Swagger 2.0 operation. Unlike OpenAPI 3, request
+// bodies are modeled as an "in: body" parameter and content types are
+// declared at the operation level via consumes/produces.
+type Swagger2Operation struct {
+	Summary     string                      `yaml:"summary"`
+	Description string                      `yaml:"description"`
+	Consumes    []string                    `yaml:"consumes,omitempty"`
+	Produces    []string                    `yaml:"produces,omitempty"`
+	Parameters  []Swagger2Parameter         `yaml:"parameters,omitempty"`
+	Responses   map[string]Swagger2Response `yaml:"responses"`
+	Tags        []string                    `yaml:"tags,omitempty"`
+}
+
+// Swagger2Parameter is an operation parameter. ToSwagger2 emits only the
+// "in: body" parameter, carrying the request schema in Schema; an
+// operation's query/path/header parameters are not converted.
+type Swagger2Parameter struct {
+	Name     string          `yaml:"name"`
+	In       string          `yaml:"in"`
+	Required bool            `yaml:"required"`
+	Schema   *Swagger2Schema `yaml:"schema,omitempty"`
+}
+
+// Swagger2Response is a single response entry.
+type Swagger2Response struct {
+	Description string          `yaml:"description"`
+	Schema      *Swagger2Schema `yaml:"schema,omitempty"`
+}
+
+// Swagger2Schema is a JSON Schema draft-4 style schema, as used by Swagger
+// 2.0. $refs point at #/definitions/... rather than #/components/schemas/....
+type Swagger2Schema struct {
+	Type        string                    `yaml:"type,omitempty"`
+	Format      string                    `yaml:"format,omitempty"`
+	Description string                    `yaml:"description,omitempty"`
+	Example     interface{}               `yaml:"example,omitempty"`
+	Properties  map[string]Swagger2Schema `yaml:"properties,omitempty"`
+	Required    []string                  `yaml:"required,omitempty"`
+	Enum        []interface{}             `yaml:"enum,omitempty"`
+	MaxLength   *int                      `yaml:"maxLength,omitempty"`
+	MinLength   *int                      `yaml:"minLength,omitempty"`
+	Maximum     *float64                  `yaml:"maximum,omitempty"`
+	Minimum     *float64                  `yaml:"minimum,omitempty"`
+	Items       *Swagger2Schema           `yaml:"items,omitempty"`
+	Ref         string                    `yaml:"$ref,omitempty"`
+}
+
+// ToSwagger2 converts spec to a Swagger 2.0 document. $refs into
+// #/components/schemas/... are rewritten to #/definitions/....
+func ToSwagger2(spec *generate.OpenAPISpec) (*Swagger2Spec, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil spec")
+	}
+
+	out := &Swagger2Spec{
+		Swagger: "2.0",
+		Info: Swagger2Info{
+			Title:       spec.Info.Title,
+			Version:     spec.Info.Version,
+			Description: spec.Info.Description,
+		},
+		Schemes: []string{"https"},
+		Paths:   make(map[string]Swagger2Path),
+	}
+
+	if spec.Components != nil {
+		out.Definitions = make(map[string]Swagger2Schema, len(spec.Components.Schemas))
+		for name, schema := range spec.Components.Schemas {
+			out.Definitions[name] = convertSchema(schema)
+		}
+	}
+
+	for path, item := range spec.Paths {
+		out.Paths[path] = Swagger2Path{
+			Post:   convertOperation(item.Post),
+			Get:    convertOperation(item.Get),
+			Put:    convertOperation(item.Put),
+			Delete: convertOperation(item.Delete),
+		}
+	}
+
+	return out, nil
+}
+
+func convertOperation(op *generate.Operation) *Swagger2Operation {
+	if op == nil {
+		return nil
+	}
+
+	out := &Swagger2Operation{
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Responses:   make(map[string]Swagger2Response, len(op.Responses)),
+	}
+
+	if op.RequestBody != nil {
+		if mt, ok := op.RequestBody.Content["application/json"]; ok {
+			out.Consumes = []string{"application/json"}
+			schema := convertSchema(mt.Schema)
+			out.Parameters = []Swagger2Parameter{
+				{Name: "body", In: "body", Required: op.RequestBody.Required, Schema: &schema},
+			}
+		}
+	}
+
+	for code, resp := range op.Responses {
+		sw2resp := Swagger2Response{Description: resp.Description}
+		if mt, ok := resp.Content["application/json"]; ok {
+			out.Produces = []string{"application/json"}
+			schema := convertSchema(mt.Schema)
+			sw2resp.Schema = &schema
+		}
+		out.Responses[code] = sw2resp
+	}
+
+	return out
+}
+
+func convertSchema(s generate.Schema) Swagger2Schema {
+	out := Swagger2Schema{
+		Type:        s.Type,
+		Format:      s.Format,
+		Description: s.Description,
+		Example:     s.Example,
+		Required:    s.Required,
+		Enum:        s.Enum,
+		MaxLength:   s.MaxLength,
+		MinLength:   s.MinLength,
+		Maximum:     s.Maximum,
+		Minimum:     s.Minimum,
+	}
+
+	if s.Ref != "" {
+		out.Ref = toDefinitionsRef(s.Ref)
+		return out
+	}
+
+	if s.Items != nil {
+		item := convertSchema(*s.Items)
+		out.Items = &item
+	}
+
+	if s.Properties != nil {
+		out.Properties = make(map[string]Swagger2Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			out.Properties[name] = convertSchema(prop)
+		}
+	}
+
+	return out
+}
+
+// toDefinitionsRef rewrites a #/components/schemas/Foo ref into its Swagger
+// 2.0 equivalent, #/definitions/Foo.
+func toDefinitionsRef(ref string) string {
+	const prefix = "#/components/schemas/"
+	if len(ref) > len(prefix) && ref[:len(prefix)] == prefix {
+		return "#/definitions/" + ref[len(prefix):]
+	}
+	return ref
+}
+
+// ToYAML renders the document as YAML.
+func (s *Swagger2Spec) ToYAML() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// ToJSON renders the document as indented JSON.
+func (s *Swagger2Spec) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
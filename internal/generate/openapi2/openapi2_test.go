@@ -0,0 +1,89 @@
+package openapi2
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/generate"
+	"github.com/xcono/novofon/internal/models"
+)
+
+func TestToSwagger2(t *testing.T) {
+	gen := generate.NewOpenAPIGenerator()
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "start.simple_call", Title: "Start simple call", HTTPMethod: "post"},
+		RequestParams: map[string]*models.Parameter{
+			"contact": {Name: "contact", Type: "string", Required: true},
+		},
+		ResponseParams: map[string]*models.Parameter{
+			"call_session_id": {Name: "call_session_id", Type: "number", Required: true},
+		},
+	}
+
+	spec, err := gen.GenerateSpec(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSpec failed: %v", err)
+	}
+
+	sw2, err := ToSwagger2(spec)
+	if err != nil {
+		t.Fatalf("ToSwagger2 failed: %v", err)
+	}
+
+	if sw2.Swagger != "2.0" {
+		t.Errorf("Expected swagger 2.0, got %s", sw2.Swagger)
+	}
+
+	path, ok := sw2.Paths["/start.simple_call"]
+	if !ok {
+		t.Fatal("Expected path /start.simple_call not found")
+	}
+	if path.Post == nil {
+		t.Fatal("Expected POST operation not found")
+	}
+	if len(path.Post.Parameters) != 1 || path.Post.Parameters[0].In != "body" {
+		t.Fatalf("Expected a single body parameter, got %+v", path.Post.Parameters)
+	}
+	if _, ok := path.Post.Responses["200"]; !ok {
+		t.Fatal("Expected 200 response not found")
+	}
+}
+
+func TestToSwagger2_NilSpec(t *testing.T) {
+	if _, err := ToSwagger2(nil); err == nil {
+		t.Error("Expected error for nil spec")
+	}
+}
+
+func TestToSwagger2_RefRewrite(t *testing.T) {
+	gen := generate.NewOpenAPIGenerator()
+	makeAPI := func(name string) *models.APIData {
+		return &models.APIData{
+			MethodInfo: &models.MethodInfo{Name: name, Title: name, HTTPMethod: "post"},
+			RequestParams: map[string]*models.Parameter{
+				"access_token": {Name: "access_token", Type: "string", Required: true},
+			},
+		}
+	}
+
+	bundle, err := gen.GenerateBundle([]*models.APIData{makeAPI("method.one"), makeAPI("method.two")})
+	if err != nil {
+		t.Fatalf("GenerateBundle failed: %v", err)
+	}
+
+	sw2, err := ToSwagger2(bundle)
+	if err != nil {
+		t.Fatalf("ToSwagger2 failed: %v", err)
+	}
+
+	for path, item := range sw2.Paths {
+		schema := item.Post.Parameters[0].Schema
+		if schema.Ref == "" {
+			t.Errorf("Expected %s body schema to carry a $ref", path)
+			continue
+		}
+		const prefix = "#/definitions/"
+		if len(schema.Ref) <= len(prefix) || schema.Ref[:len(prefix)] != prefix {
+			t.Errorf("Expected ref to point at #/definitions/..., got %s", schema.Ref)
+		}
+	}
+}
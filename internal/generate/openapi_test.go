@@ -265,6 +265,132 @@ func TestOpenAPIGenerator_IsValidHTTPStatusCode(t *testing.T) {
 	}
 }
 
+func TestOpenAPIGenerator_GenerateBundle(t *testing.T) {
+	generator := NewOpenAPIGenerator()
+
+	makeAPI := func(name string) *models.APIData {
+		return &models.APIData{
+			MethodInfo: &models.MethodInfo{Name: name, Title: name, HTTPMethod: "post"},
+			RequestParams: map[string]*models.Parameter{
+				"access_token": {Name: "access_token", Type: "string", Required: true},
+			},
+			ResponseParams: map[string]*models.Parameter{
+				"result": {Name: "result", Type: "string", Required: true},
+			},
+		}
+	}
+
+	bundle, err := generator.GenerateBundle([]*models.APIData{makeAPI("method.one"), makeAPI("method.two")})
+	if err != nil {
+		t.Fatalf("GenerateBundle failed: %v", err)
+	}
+
+	if len(bundle.Paths) != 2 {
+		t.Errorf("Expected 2 paths, got %d", len(bundle.Paths))
+	}
+
+	if bundle.Components == nil || len(bundle.Components.Schemas) == 0 {
+		t.Fatal("Expected shared schemas to be promoted into components")
+	}
+
+	// Both methods share the same JSON-RPC request envelope shape, so the
+	// request body of each path should now be a $ref into components.
+	for path, pathItem := range bundle.Paths {
+		schema := pathItem.Post.RequestBody.Content["application/json"].Schema
+		if schema.Ref == "" {
+			t.Errorf("Expected %s request body to be promoted to a $ref, got inline schema", path)
+		}
+	}
+}
+
+func TestOpenAPIGenerator_GenerateBundle_EmptyData(t *testing.T) {
+	generator := NewOpenAPIGenerator()
+
+	_, err := generator.GenerateBundle(nil)
+	if err == nil {
+		t.Error("Expected error for empty API data slice")
+	}
+}
+
+func TestOpenAPIGenerator_GenerateSpec_OAS31(t *testing.T) {
+	generator := NewOpenAPIGenerator(Options{Version: VersionOAS31})
+
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "test.method", Title: "Test Method", HTTPMethod: "post"},
+		RequestParams: map[string]*models.Parameter{
+			"param1": {Name: "param1", Type: "string", Required: true, Description: "может быть null"},
+		},
+	}
+
+	spec, err := generator.GenerateSpec(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSpec failed: %v", err)
+	}
+
+	if spec.OpenAPI != VersionOAS31 {
+		t.Errorf("Expected OpenAPI version %s, got %s", VersionOAS31, spec.OpenAPI)
+	}
+
+	yamlData, err := spec.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+
+	yamlStr := string(yamlData)
+	if !contains(yamlStr, "- string") || !contains(yamlStr, "- \"null\"") {
+		t.Errorf("Expected nullable param1 to render as a [type, null] array, got:\n%s", yamlStr)
+	}
+	if contains(yamlStr, "nullable: true") {
+		t.Error("Expected 3.1 output not to use the OAS 3.0 nullable flag")
+	}
+}
+
+func TestOpenAPIGenerator_GenerateOperation_NonBodyParams(t *testing.T) {
+	generator := NewOpenAPIGenerator()
+
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "test.method", Title: "Test Method", HTTPMethod: "get"},
+		RequestParams: map[string]*models.Parameter{
+			"call_session_id": {Name: "call_session_id", Type: "string", Required: true, In: "query"},
+			"access_token":    {Name: "access_token", Type: "string", Required: true},
+		},
+	}
+
+	spec, err := generator.GenerateSpec(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSpec failed: %v", err)
+	}
+
+	// HTTPMethod "get" routes the operation onto pathItem.Get (see
+	// GenerateSpec's method switch); the partitioning this test exercises
+	// is orthogonal to that routing, so assert against wherever the
+	// operation actually landed rather than assuming POST.
+	operation := spec.Paths["/test.method"].Get
+	if operation == nil {
+		t.Fatal("Expected a GET operation at /test.method")
+	}
+	if operation.RequestBody == nil {
+		t.Fatal("Expected body param access_token to still produce a request body")
+	}
+
+	if len(operation.Parameters) != 1 {
+		t.Fatalf("Expected 1 query parameter, got %d", len(operation.Parameters))
+	}
+	param := operation.Parameters[0]
+	if param.Name != "call_session_id" || param.In != "query" || !param.Required {
+		t.Errorf("Unexpected parameter: %+v", param)
+	}
+
+	bodySchema := operation.RequestBody.Content["application/json"].Schema
+	paramsSchema := bodySchema.Properties["params"]
+	if _, ok := paramsSchema.Properties["call_session_id"]; ok {
+		t.Error("Expected query param to be excluded from the JSON-RPC body")
+	}
+	if _, ok := paramsSchema.Properties["access_token"]; !ok {
+		t.Error("Expected body param to remain in the JSON-RPC body")
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -0,0 +1,288 @@
+// Package validate provides a runtime JSON-RPC request/response validator
+// compiled directly from a generated OpenAPISpec, so real Novofon traffic can
+// be asserted to conform to the spec the parser scraped from the docs.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xcono/novofon/internal/generate"
+)
+
+// FieldError is a single validation failure against a field path.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) String() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// MultiError aggregates every FieldError found during a single validation
+// pass, rather than failing on the first one, mirroring kin-openapi's
+// MultiError.
+type MultiError struct {
+	Errors []FieldError
+}
+
+func (m *MultiError) add(field, message string) {
+	m.Errors = append(m.Errors, FieldError{Field: field, Message: message})
+}
+
+func (m *MultiError) Error() string {
+	parts := make([]string, len(m.Errors))
+	for i, e := range m.Errors {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// methodSchemas holds the pre-resolved request/response schemas for a single
+// JSON-RPC method.
+type methodSchemas struct {
+	request  generate.Schema
+	response generate.Schema
+}
+
+// Validator validates JSON-RPC request and response bodies against the
+// schemas of an OpenAPISpec, keyed by method name.
+type Validator struct {
+	methods    map[string]methodSchemas
+	components map[string]generate.Schema
+}
+
+// NewValidator compiles spec into a per-method schema tree. It resolves
+// $ref entries against spec.Components as schemas are walked.
+func NewValidator(spec *generate.OpenAPISpec) (*Validator, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil spec")
+	}
+
+	v := &Validator{methods: make(map[string]methodSchemas)}
+	if spec.Components != nil {
+		v.components = spec.Components.Schemas
+	}
+
+	for path, item := range spec.Paths {
+		op := operationFor(item)
+		if op == nil {
+			continue
+		}
+		method := strings.TrimPrefix(path, "/")
+
+		var entry methodSchemas
+		if op.RequestBody != nil {
+			if mt, ok := op.RequestBody.Content["application/json"]; ok {
+				entry.request = v.resolve(mt.Schema)
+			}
+		}
+		if resp, ok := op.Responses["200"]; ok {
+			if mt, ok := resp.Content["application/json"]; ok {
+				entry.response = v.resolve(mt.Schema)
+			}
+		}
+		v.methods[method] = entry
+	}
+
+	return v, nil
+}
+
+// ValidateRequest checks a raw JSON-RPC request body against the compiled
+// schema for method, enforcing the envelope (jsonrpc=="2.0", presence of id,
+// method, params) plus the params schema itself.
+func (v *Validator) ValidateRequest(method string, body []byte) error {
+	entry, ok := v.methods[method]
+	if !ok {
+		return fmt.Errorf("unknown method %q", method)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	me := &MultiError{}
+	if jr, ok := data["jsonrpc"]; !ok || jr != "2.0" {
+		me.add("jsonrpc", `must equal "2.0"`)
+	}
+	if _, ok := data["id"]; !ok {
+		me.add("id", "is required")
+	}
+	if m, ok := data["method"]; !ok {
+		me.add("method", "is required")
+	} else if m != method {
+		me.add("method", fmt.Sprintf("expected %q, got %v", method, m))
+	}
+
+	paramsSchema := v.resolve(entry.request.Properties["params"])
+	params, hasParams := data["params"].(map[string]interface{})
+	if _, present := data["params"]; !present {
+		me.add("params", "is required")
+	} else if !hasParams {
+		me.add("params", "must be an object")
+	} else {
+		v.validateObject("params", paramsSchema, params, me)
+	}
+
+	if len(me.Errors) > 0 {
+		return me
+	}
+	return nil
+}
+
+// ValidateResponse checks a raw JSON-RPC response body's result.data against
+// the compiled schema for method.
+func (v *Validator) ValidateResponse(method string, body []byte) error {
+	entry, ok := v.methods[method]
+	if !ok {
+		return fmt.Errorf("unknown method %q", method)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	me := &MultiError{}
+	if jr, ok := data["jsonrpc"]; !ok || jr != "2.0" {
+		me.add("jsonrpc", `must equal "2.0"`)
+	}
+
+	result, ok := data["result"].(map[string]interface{})
+	if !ok {
+		me.add("result", "is required")
+		return me
+	}
+
+	resultSchema := v.resolve(entry.response.Properties["result"])
+	dataSchema := v.resolve(resultSchema.Properties["data"])
+	dataVal, _ := result["data"].(map[string]interface{})
+	v.validateObject("result.data", dataSchema, dataVal, me)
+
+	if len(me.Errors) > 0 {
+		return me
+	}
+	return nil
+}
+
+// resolve follows a single $ref hop into v.components; unresolved refs are
+// returned unchanged so validation degrades to "no constraints" rather than
+// panicking.
+func (v *Validator) resolve(s generate.Schema) generate.Schema {
+	if s.Ref == "" {
+		return s
+	}
+	name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+	if resolved, ok := v.components[name]; ok {
+		return resolved
+	}
+	return s
+}
+
+func (v *Validator) validateObject(path string, schema generate.Schema, data map[string]interface{}, me *MultiError) {
+	for _, name := range schema.Required {
+		if _, ok := data[name]; !ok {
+			me.add(path+"."+name, "is required")
+		}
+	}
+	for name, val := range data {
+		propSchema, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		v.validateValue(path+"."+name, v.resolve(propSchema), val, me)
+	}
+}
+
+func (v *Validator) validateValue(path string, schema generate.Schema, val interface{}, me *MultiError) {
+	switch schema.Type {
+	case "string":
+		s, ok := val.(string)
+		if !ok {
+			me.add(path, "must be a string")
+			return
+		}
+		if schema.MaxLength != nil && len(s) > *schema.MaxLength {
+			me.add(path, "exceeds maxLength")
+		}
+		if schema.MinLength != nil && len(s) < *schema.MinLength {
+			me.add(path, "shorter than minLength")
+		}
+		if len(schema.Enum) > 0 && !enumContains(schema.Enum, s) {
+			me.add(path, "value not in enum")
+		}
+	case "number":
+		n, ok := toFloat(val)
+		if !ok {
+			me.add(path, "must be a number")
+			return
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			me.add(path, "exceeds maximum")
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			me.add(path, "below minimum")
+		}
+	case "boolean":
+		if _, ok := val.(bool); !ok {
+			me.add(path, "must be a boolean")
+		}
+	case "array":
+		arr, ok := val.([]interface{})
+		if !ok {
+			me.add(path, "must be an array")
+			return
+		}
+		if schema.Items == nil {
+			return
+		}
+		item := v.resolve(*schema.Items)
+		for i, elem := range arr {
+			v.validateValue(fmt.Sprintf("%s[%d]", path, i), item, elem, me)
+		}
+	case "object":
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			me.add(path, "must be an object")
+			return
+		}
+		v.validateObject(path, schema, obj, me)
+	}
+}
+
+func enumContains(enum []interface{}, s string) bool {
+	for _, v := range enum {
+		if fmt.Sprintf("%v", v) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(val interface{}) (float64, bool) {
+	switch n := val.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// operationFor returns whichever HTTP verb is populated on a PathItem.
+func operationFor(item generate.PathItem) *generate.Operation {
+	switch {
+	case item.Post != nil:
+		return item.Post
+	case item.Get != nil:
+		return item.Get
+	case item.Put != nil:
+		return item.Put
+	case item.Delete != nil:
+		return item.Delete
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/generate"
+)
+
+func intPtr(i int) *int { return &i }
+
+func floatPtr(f float64) *float64 { return &f }
+
+func testSpec() *generate.OpenAPISpec {
+	return &generate.OpenAPISpec{
+		Paths: map[string]generate.PathItem{
+			"/test.method": {
+				Post: &generate.Operation{
+					RequestBody: &generate.RequestBody{
+						Required: true,
+						Content: map[string]generate.MediaType{
+							"application/json": {
+								Schema: generate.Schema{
+									Type: "object",
+									Properties: map[string]generate.Schema{
+										"params": {
+											Type: "object",
+											Properties: map[string]generate.Schema{
+												"access_token": {Type: "string", MinLength: intPtr(1), MaxLength: intPtr(64)},
+												"status":       {Type: "string", Enum: []interface{}{"active", "inactive"}},
+												"limit":        {Type: "number", Minimum: floatPtr(1), Maximum: floatPtr(100)},
+												"tags": {
+													Type:  "array",
+													Items: &generate.Schema{Type: "string"},
+												},
+											},
+											Required: []string{"access_token"},
+										},
+									},
+								},
+							},
+						},
+					},
+					Responses: map[string]generate.Response{
+						"200": {
+							Content: map[string]generate.MediaType{
+								"application/json": {
+									Schema: generate.Schema{
+										Type: "object",
+										Properties: map[string]generate.Schema{
+											"result": {
+												Type: "object",
+												Properties: map[string]generate.Schema{
+													"data": {
+														Type: "object",
+														Properties: map[string]generate.Schema{
+															"id": {Type: "string"},
+														},
+														Required: []string{"id"},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewValidator_NilSpec(t *testing.T) {
+	if _, err := NewValidator(nil); err == nil {
+		t.Error("expected an error for a nil spec")
+	}
+}
+
+func TestValidateRequest_Valid(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"test.method","params":{"access_token":"abc","status":"active","limit":10,"tags":["a","b"]}}`)
+	if err := v.ValidateRequest("test.method", body); err != nil {
+		t.Errorf("expected valid request to pass, got: %v", err)
+	}
+}
+
+func TestValidateRequest_UnknownMethod(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	if err := v.ValidateRequest("does.not.exist", []byte(`{}`)); err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+}
+
+func TestValidateRequest_InvalidJSON(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	if err := v.ValidateRequest("test.method", []byte(`not json`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestValidateRequest_EnvelopeErrors(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"1.0","method":"test.method","params":{"access_token":"abc"}}`)
+	err = v.ValidateRequest("test.method", body)
+	if err == nil {
+		t.Fatal("expected envelope violations to fail validation")
+	}
+
+	me, ok := err.(*MultiError)
+	if !ok {
+		t.Fatalf("expected a *MultiError, got %T", err)
+	}
+
+	wantFields := map[string]bool{"jsonrpc": false, "id": false}
+	for _, fe := range me.Errors {
+		if _, ok := wantFields[fe.Field]; ok {
+			wantFields[fe.Field] = true
+		}
+	}
+	for field, found := range wantFields {
+		if !found {
+			t.Errorf("expected a %q error, got: %v", field, me.Errors)
+		}
+	}
+}
+
+func TestValidateRequest_MissingRequiredParam(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"test.method","params":{}}`)
+	err = v.ValidateRequest("test.method", body)
+	if err == nil {
+		t.Fatal("expected a missing required param to fail validation")
+	}
+	me := err.(*MultiError)
+	found := false
+	for _, fe := range me.Errors {
+		if fe.Field == "params.access_token" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a params.access_token required error, got: %v", me.Errors)
+	}
+}
+
+func TestValidateRequest_ParamsMustBeObject(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"test.method","params":"not an object"}`)
+	err = v.ValidateRequest("test.method", body)
+	if err == nil {
+		t.Fatal("expected non-object params to fail validation")
+	}
+}
+
+func TestValidateRequest_ParamsRequired(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"test.method"}`)
+	err = v.ValidateRequest("test.method", body)
+	if err == nil {
+		t.Fatal("expected missing params to fail validation")
+	}
+}
+
+func TestValidateRequest_EnumViolation(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"test.method","params":{"access_token":"abc","status":"bogus"}}`)
+	err = v.ValidateRequest("test.method", body)
+	if err == nil {
+		t.Fatal("expected an out-of-enum value to fail validation")
+	}
+}
+
+func TestValidateRequest_MinMaxViolations(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"string too short", `{"jsonrpc":"2.0","id":1,"method":"test.method","params":{"access_token":""}}`},
+		{"number below minimum", `{"jsonrpc":"2.0","id":1,"method":"test.method","params":{"access_token":"abc","limit":0}}`},
+		{"number above maximum", `{"jsonrpc":"2.0","id":1,"method":"test.method","params":{"access_token":"abc","limit":101}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.ValidateRequest("test.method", []byte(tt.body)); err == nil {
+				t.Error("expected validation to fail")
+			}
+		})
+	}
+}
+
+func TestValidateRequest_ArrayItemViolation(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"test.method","params":{"access_token":"abc","tags":[1,2]}}`)
+	if err := v.ValidateRequest("test.method", body); err == nil {
+		t.Error("expected wrong-typed array elements to fail validation")
+	}
+}
+
+func TestValidateResponse_Valid(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"result":{"data":{"id":"abc"}}}`)
+	if err := v.ValidateResponse("test.method", body); err != nil {
+		t.Errorf("expected valid response to pass, got: %v", err)
+	}
+}
+
+func TestValidateResponse_MissingResult(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1}`)
+	if err := v.ValidateResponse("test.method", body); err == nil {
+		t.Error("expected a missing result to fail validation")
+	}
+}
+
+func TestValidateResponse_MissingRequiredDataField(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"result":{"data":{}}}`)
+	err = v.ValidateResponse("test.method", body)
+	if err == nil {
+		t.Fatal("expected a missing required result.data.id to fail validation")
+	}
+	me := err.(*MultiError)
+	found := false
+	for _, fe := range me.Errors {
+		if fe.Field == "result.data.id" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a result.data.id required error, got: %v", me.Errors)
+	}
+}
+
+func TestValidateResponse_UnknownMethod(t *testing.T) {
+	v, err := NewValidator(testSpec())
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	if err := v.ValidateResponse("does.not.exist", []byte(`{}`)); err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+}
+
+func TestValidateRequest_RefResolution(t *testing.T) {
+	// "address" is a $ref to a shared components.schemas entry, resolved one
+	// hop at a time as validateObject/validateValue descend into it.
+	spec := &generate.OpenAPISpec{
+		Components: &generate.Components{
+			Schemas: map[string]generate.Schema{
+				"Address": {
+					Type: "object",
+					Properties: map[string]generate.Schema{
+						"city": {Type: "string"},
+					},
+					Required: []string{"city"},
+				},
+			},
+		},
+		Paths: map[string]generate.PathItem{
+			"/ref.method": {
+				Post: &generate.Operation{
+					RequestBody: &generate.RequestBody{
+						Content: map[string]generate.MediaType{
+							"application/json": {
+								Schema: generate.Schema{
+									Type: "object",
+									Properties: map[string]generate.Schema{
+										"params": {
+											Type: "object",
+											Properties: map[string]generate.Schema{
+												"address": {Ref: "#/components/schemas/Address"},
+											},
+											Required: []string{"address"},
+										},
+									},
+								},
+							},
+						},
+					},
+					Responses: map[string]generate.Response{},
+				},
+			},
+		},
+	}
+
+	v, err := NewValidator(spec)
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"ref.method","params":{"address":{}}}`)
+	err = v.ValidateRequest("ref.method", body)
+	if err == nil {
+		t.Fatal("expected the $ref'd address schema to still enforce its required field")
+	}
+	me := err.(*MultiError)
+	found := false
+	for _, fe := range me.Errors {
+		if fe.Field == "params.address.city" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a params.address.city required error resolved through $ref, got: %v", me.Errors)
+	}
+}
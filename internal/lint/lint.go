@@ -0,0 +1,434 @@
+// Package lint runs pluggable linters over generated/bundled OpenAPI spec
+// files, catching merge collisions and schema regressions (duplicate
+// operationIds, missing response schemas, inconsistent path parameters)
+// before they reach downstream consumers.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity classifies how serious a Finding is. Only SeverityError causes
+// the process to exit non-zero.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding is a single lint violation against one spec file.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Message  string
+	Path     string // JSON-pointer-ish path within the document, e.g. paths./foo.post
+	File     string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("[%s] %s %s: %s (%s)", f.Severity, f.File, f.Path, f.Message, f.Rule)
+}
+
+// Linter runs a single lint pass against a spec file on disk.
+type Linter interface {
+	Run(ctx context.Context, specPath string) ([]Finding, error)
+}
+
+// LinterConfig selects and configures one Linter to run as part of
+// BundlingConfig.Linters.
+type LinterConfig struct {
+	// Type is "native" (the built-in YAML rule engine) or "external"
+	// (shell out to a linter on PATH, e.g. spectral/vacuum).
+	Type string
+
+	// RulesFile is the YAML rule file for a "native" linter.
+	RulesFile string
+
+	// Command and Args describe the external linter invocation for a
+	// "external" linter, e.g. Command: "spectral", Args: []string{"lint"}.
+	Command string
+	Args    []string
+}
+
+// Build constructs the Linter described by cfg.
+func (cfg LinterConfig) Build() (Linter, error) {
+	switch cfg.Type {
+	case "native", "":
+		return NewRuleEngine(cfg.RulesFile)
+	case "external":
+		return NewExternalLinter(cfg.Command, cfg.Args), nil
+	default:
+		return nil, fmt.Errorf("unknown linter type %q", cfg.Type)
+	}
+}
+
+// RunAll runs every configured linter against specPath, aggregating errors
+// from the linters themselves (not findings) with multierr so one linter
+// failing to run doesn't hide the others' results.
+func RunAll(ctx context.Context, linters []Linter, specPath string) ([]Finding, error) {
+	var (
+		all []Finding
+		err error
+	)
+	for _, l := range linters {
+		findings, runErr := l.Run(ctx, specPath)
+		if runErr != nil {
+			err = multierr.Append(err, fmt.Errorf("%T: %w", l, runErr))
+			continue
+		}
+		all = append(all, findings...)
+	}
+	return all, err
+}
+
+// HasErrors reports whether any finding has SeverityError.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Summarize groups findings by rule, then file, for a human-readable report.
+func Summarize(findings []Finding) string {
+	if len(findings) == 0 {
+		return "no findings"
+	}
+
+	byRule := make(map[string][]Finding)
+	for _, f := range findings {
+		byRule[f.Rule] = append(byRule[f.Rule], f)
+	}
+
+	rules := make([]string, 0, len(byRule))
+	for rule := range byRule {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	var b strings.Builder
+	for _, rule := range rules {
+		group := byRule[rule]
+		fmt.Fprintf(&b, "%s (%d)\n", rule, len(group))
+
+		byFile := make(map[string][]Finding)
+		for _, f := range group {
+			byFile[f.File] = append(byFile[f.File], f)
+		}
+		files := make([]string, 0, len(byFile))
+		for file := range byFile {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			for _, f := range byFile[file] {
+				fmt.Fprintf(&b, "  %s %s: %s\n", f.Severity, f.Path, f.Message)
+			}
+		}
+	}
+	return b.String()
+}
+
+// ExternalLinter shells out to a linter binary on PATH (e.g. spectral lint,
+// vacuum lint). Its output isn't parsed into structured Findings since the
+// tools' formats vary; instead a non-zero exit is surfaced as a single
+// SeverityError finding carrying the combined output.
+type ExternalLinter struct {
+	Command string
+	Args    []string
+}
+
+// NewExternalLinter builds an ExternalLinter for command with args appended
+// before the spec path.
+func NewExternalLinter(command string, args []string) *ExternalLinter {
+	return &ExternalLinter{Command: command, Args: args}
+}
+
+// Run invokes the external linter against specPath, skipping silently (no
+// findings, no error) if the binary isn't on PATH so deployments without
+// spectral/vacuum installed aren't penalized.
+func (l *ExternalLinter) Run(ctx context.Context, specPath string) ([]Finding, error) {
+	if _, err := exec.LookPath(l.Command); err != nil {
+		return nil, nil
+	}
+
+	args := append(append([]string{}, l.Args...), specPath)
+	cmd := exec.CommandContext(ctx, l.Command, args...)
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil, nil
+	}
+
+	return []Finding{{
+		Rule:     l.Command,
+		Severity: SeverityError,
+		Message:  strings.TrimSpace(string(output)),
+		Path:     "$",
+		File:     specPath,
+	}}, nil
+}
+
+// Rule is a single native rule loaded from a YAML rules file.
+type Rule struct {
+	ID       string   `yaml:"id"`
+	Type     string   `yaml:"type"`
+	Severity Severity `yaml:"severity"`
+	Message  string   `yaml:"message"`
+}
+
+// RuleSet is the top-level shape of a native rules YAML file.
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// defaultRules mirrors Spectral's oas ruleset at the subset this package
+// implements: required info/paths fields, unique operationIds, path/operation
+// parameter consistency, response schema presence, and tag naming.
+var defaultRules = RuleSet{
+	Rules: []Rule{
+		{ID: "required-fields", Type: "required-fields", Severity: SeverityError, Message: "spec is missing required top-level fields"},
+		{ID: "operation-id-unique", Type: "operation-id-unique", Severity: SeverityError, Message: "duplicate operationId"},
+		{ID: "path-param-consistency", Type: "path-param-consistency", Severity: SeverityWarning, Message: "path parameter has no matching operation parameter"},
+		{ID: "response-schema-presence", Type: "response-schema-presence", Severity: SeverityWarning, Message: "response is missing a schema"},
+		{ID: "tag-naming", Type: "tag-naming", Severity: SeverityInfo, Message: "tag should be lowercase"},
+	},
+}
+
+// RuleEngine is the native Go rule engine: it loads a RuleSet of rules
+// (each identified by a Type) and evaluates each against the parsed spec.
+type RuleEngine struct {
+	rules RuleSet
+}
+
+// NewRuleEngine loads rules from rulesFile, falling back to defaultRules
+// when rulesFile is empty.
+func NewRuleEngine(rulesFile string) (*RuleEngine, error) {
+	if rulesFile == "" {
+		return &RuleEngine{rules: defaultRules}, nil
+	}
+
+	data, err := os.ReadFile(rulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("read rules file: %w", err)
+	}
+
+	var rs RuleSet
+	if err := yaml.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("parse rules file: %w", err)
+	}
+
+	return &RuleEngine{rules: rs}, nil
+}
+
+// Run loads the spec at specPath and evaluates every configured rule.
+func (e *RuleEngine) Run(ctx context.Context, specPath string) ([]Finding, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("read spec: %w", err)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse spec: %w", err)
+	}
+
+	var findings []Finding
+	for _, rule := range e.rules.Rules {
+		check, ok := ruleCheckers[rule.Type]
+		if !ok {
+			continue
+		}
+		findings = append(findings, check(rule, specPath, doc)...)
+	}
+	return findings, nil
+}
+
+type ruleChecker func(rule Rule, file string, doc map[string]interface{}) []Finding
+
+var ruleCheckers = map[string]ruleChecker{
+	"required-fields":          checkRequiredFields,
+	"operation-id-unique":      checkOperationIDUnique,
+	"path-param-consistency":   checkPathParamConsistency,
+	"response-schema-presence": checkResponseSchemaPresence,
+	"tag-naming":               checkTagNaming,
+}
+
+func checkRequiredFields(rule Rule, file string, doc map[string]interface{}) []Finding {
+	var findings []Finding
+	for _, field := range []string{"openapi", "info", "paths"} {
+		if _, ok := doc[field]; !ok {
+			findings = append(findings, Finding{Rule: rule.ID, Severity: rule.Severity, Message: rule.Message, Path: "$." + field, File: file})
+		}
+	}
+	return findings
+}
+
+func checkOperationIDUnique(rule Rule, file string, doc map[string]interface{}) []Finding {
+	paths, _ := doc["paths"].(map[string]interface{})
+	seen := make(map[string]string)
+	var findings []Finding
+
+	forEachOperation(paths, func(path, method string, op map[string]interface{}) {
+		opID, ok := op["operationId"].(string)
+		if !ok || opID == "" {
+			return
+		}
+		if firstPath, exists := seen[opID]; exists {
+			findings = append(findings, Finding{
+				Rule: rule.ID, Severity: rule.Severity,
+				Message: fmt.Sprintf("%s: operationId %q already used at %s", rule.Message, opID, firstPath),
+				Path:    fmt.Sprintf("paths.%s.%s", path, method), File: file,
+			})
+			return
+		}
+		seen[opID] = path
+	})
+	return findings
+}
+
+func checkPathParamConsistency(rule Rule, file string, doc map[string]interface{}) []Finding {
+	paths, _ := doc["paths"].(map[string]interface{})
+	var findings []Finding
+
+	for path := range paths {
+		templated := pathParamNames(path)
+		if len(templated) == 0 {
+			continue
+		}
+		pathItem, _ := paths[path].(map[string]interface{})
+		forEachOperation(map[string]interface{}{path: pathItem}, func(_, method string, op map[string]interface{}) {
+			declared := make(map[string]bool)
+			if params, ok := op["parameters"].([]interface{}); ok {
+				for _, p := range params {
+					pm, ok := p.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					if name, ok := pm["name"].(string); ok && pm["in"] == "path" {
+						declared[name] = true
+					}
+				}
+			}
+			for _, name := range templated {
+				if !declared[name] {
+					findings = append(findings, Finding{
+						Rule: rule.ID, Severity: rule.Severity,
+						Message: fmt.Sprintf("%s: %q", rule.Message, name),
+						Path:    fmt.Sprintf("paths.%s.%s", path, method), File: file,
+					})
+				}
+			}
+		})
+	}
+	return findings
+}
+
+func checkResponseSchemaPresence(rule Rule, file string, doc map[string]interface{}) []Finding {
+	paths, _ := doc["paths"].(map[string]interface{})
+	var findings []Finding
+
+	forEachOperation(paths, func(path, method string, op map[string]interface{}) {
+		responses, ok := op["responses"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for code, respRaw := range responses {
+			resp, ok := respRaw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			content, ok := resp["content"].(map[string]interface{})
+			if !ok {
+				findings = append(findings, Finding{
+					Rule: rule.ID, Severity: rule.Severity, Message: rule.Message,
+					Path: fmt.Sprintf("paths.%s.%s.responses.%s", path, method, code), File: file,
+				})
+				continue
+			}
+			for ct, mtRaw := range content {
+				mt, ok := mtRaw.(map[string]interface{})
+				if !ok || mt["schema"] == nil {
+					findings = append(findings, Finding{
+						Rule: rule.ID, Severity: rule.Severity, Message: rule.Message,
+						Path: fmt.Sprintf("paths.%s.%s.responses.%s.content.%s", path, method, code, ct), File: file,
+					})
+				}
+			}
+		}
+	})
+	return findings
+}
+
+func checkTagNaming(rule Rule, file string, doc map[string]interface{}) []Finding {
+	paths, _ := doc["paths"].(map[string]interface{})
+	var findings []Finding
+
+	forEachOperation(paths, func(path, method string, op map[string]interface{}) {
+		tags, ok := op["tags"].([]interface{})
+		if !ok {
+			return
+		}
+		for _, tagRaw := range tags {
+			tag, ok := tagRaw.(string)
+			if !ok {
+				continue
+			}
+			if tag != strings.ToLower(tag) {
+				findings = append(findings, Finding{
+					Rule: rule.ID, Severity: rule.Severity,
+					Message: fmt.Sprintf("%s: %q", rule.Message, tag),
+					Path:    fmt.Sprintf("paths.%s.%s.tags", path, method), File: file,
+				})
+			}
+		}
+	})
+	return findings
+}
+
+// forEachOperation walks every HTTP-method operation object under paths.
+func forEachOperation(paths map[string]interface{}, visit func(path, method string, op map[string]interface{})) {
+	for path, itemRaw := range paths {
+		item, ok := itemRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, method := range []string{"get", "post", "put", "delete", "patch"} {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			visit(path, method, op)
+		}
+	}
+}
+
+// pathParamNames extracts {name}-style template parameters from a path.
+func pathParamNames(path string) []string {
+	var names []string
+	for {
+		start := strings.Index(path, "{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(path[start:], "}")
+		if end == -1 {
+			break
+		}
+		names = append(names, path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+	return names
+}
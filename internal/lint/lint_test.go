@@ -0,0 +1,136 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test spec: %v", err)
+	}
+	return path
+}
+
+func TestRuleEngine_RequiredFields(t *testing.T) {
+	path := writeSpec(t, "info:\n  title: x\n")
+
+	engine, err := NewRuleEngine("")
+	if err != nil {
+		t.Fatalf("NewRuleEngine failed: %v", err)
+	}
+
+	findings, err := engine.Run(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !HasErrors(findings) {
+		t.Error("Expected a required-fields error for a spec missing openapi/paths")
+	}
+}
+
+func TestRuleEngine_OperationIDUnique(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: x
+paths:
+  /a:
+    post:
+      operationId: dup
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+  /b:
+    post:
+      operationId: dup
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+`
+	path := writeSpec(t, spec)
+
+	engine, err := NewRuleEngine("")
+	if err != nil {
+		t.Fatalf("NewRuleEngine failed: %v", err)
+	}
+
+	findings, err := engine.Run(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	found := false
+	for _, f := range findings {
+		if f.Rule == "operation-id-unique" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a duplicate operationId finding")
+	}
+}
+
+func TestRuleEngine_ResponseSchemaPresence(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: x
+paths:
+  /a:
+    post:
+      responses:
+        "200":
+          description: ok
+`
+	path := writeSpec(t, spec)
+
+	engine, err := NewRuleEngine("")
+	if err != nil {
+		t.Fatalf("NewRuleEngine failed: %v", err)
+	}
+
+	findings, err := engine.Run(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	found := false
+	for _, f := range findings {
+		if f.Rule == "response-schema-presence" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a response-schema-presence finding")
+	}
+}
+
+func TestExternalLinter_MissingBinary(t *testing.T) {
+	l := NewExternalLinter("definitely-not-a-real-linter-binary", nil)
+	findings, err := l.Run(context.Background(), "doesnt-matter.yaml")
+	if err != nil {
+		t.Fatalf("Expected no error for a missing binary, got %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("Expected no findings when the binary isn't on PATH, got %v", findings)
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	if got := Summarize(nil); got != "no findings" {
+		t.Errorf("Expected 'no findings', got %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+// MethodInfo represents basic method information extracted from HTML
+type MethodInfo struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AccessLevel string `json:"access_level"`
+	HTTPMethod  string `json:"http_method"`
+	// Version is this method's date-based (e.g. "2024-05-01") or semver
+	// release identifier. The parsers in internal/parse do not set it;
+	// empty means "unversioned" and sorts before every explicit version.
+	Version string `json:"version,omitempty"`
+	// Stability is one of "wip", "beta", "ga", "deprecated". Empty is
+	// treated as "ga" for resolution purposes.
+	Stability string `json:"stability,omitempty"`
+}
+
+// Parameter represents a single parameter (request or response)
+type Parameter struct {
+	Name           string            `json:"name"`
+	Type           string            `json:"type"`
+	Required       bool              `json:"required"`
+	Description    string            `json:"description"`
+	AllowedValues  string            `json:"allowed_values,omitempty"`
+	ArrayItemType  string            `json:"array_item_type,omitempty"`
+	AdditionalInfo map[string]string `json:"additional_info,omitempty"`
+	// In is the parameter's location when surfaced outside the JSON-RPC
+	// envelope: "body" (default), "query", "path", or "header". The parsers
+	// in internal/parse do not set it; an empty value is treated as "body".
+	In string `json:"in,omitempty"`
+}
+
+// APIData represents complete parsed API data from HTML documentation
+type APIData struct {
+	MethodInfo     *MethodInfo            `json:"method_info"`
+	RequestParams  map[string]*Parameter  `json:"request_params"`
+	ResponseParams map[string]*Parameter  `json:"response_params"`
+	RequestJSON    map[string]interface{} `json:"request_json,omitempty"`
+	ResponseJSON   map[string]interface{} `json:"response_json,omitempty"`
+	ErrorInfo      *ErrorInfo             `json:"error_info,omitempty"`
+	// RequestParamOrder and ResponseParamOrder record RequestParams'/
+	// ResponseParams' keys in the order the parser discovered them in the
+	// source HTML, since the maps themselves don't preserve it.
+	// validate.GenerateSchemaFromAPIData iterates these for HTML-order
+	// schema output; the OpenAPI generators do not use them and emit
+	// parameters in alphabetical order.
+	RequestParamOrder  []string `json:"-"`
+	ResponseParamOrder []string `json:"-"`
+}
+
+// ErrorInfo represents error information extracted from HTML
+type ErrorInfo struct {
+	Errors []Error `json:"errors"`
+}
+
+// Error represents a single error from the documentation
+type Error struct {
+	Code        string `json:"code"`
+	Mnemonic    string `json:"mnemonic"`
+	Description string `json:"description"`
+}
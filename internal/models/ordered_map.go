@@ -0,0 +1,107 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OrderedMap is a string-keyed map that preserves insertion order across
+// both JSON and YAML marshaling. Go's map iteration order is randomized,
+// which made generated schemas and OpenAPI fragments diff noisily from
+// run to run even when nothing meaningful changed; callers that want
+// deterministic, HTML-discovery-order output build one of these instead
+// of a plain map[string]interface{}.
+type OrderedMap struct {
+	entries []orderedMapEntry
+	index   map[string]int
+}
+
+type orderedMapEntry struct {
+	Key   string
+	Value interface{}
+}
+
+// NewOrderedMap returns an empty OrderedMap.
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{index: make(map[string]int)}
+}
+
+// Set adds key/value, or updates value in place if key was already set
+// (without changing its position).
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if i, ok := m.index[key]; ok {
+		m.entries[i].Value = value
+		return
+	}
+	m.index[key] = len(m.entries)
+	m.entries = append(m.entries, orderedMapEntry{Key: key, Value: value})
+}
+
+// Get returns the value stored under key, if any.
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	i, ok := m.index[key]
+	if !ok {
+		return nil, false
+	}
+	return m.entries[i].Value, true
+}
+
+// Keys returns the keys in insertion order.
+func (m *OrderedMap) Keys() []string {
+	keys := make([]string, len(m.entries))
+	for i, e := range m.entries {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+// Len returns the number of entries.
+func (m *OrderedMap) Len() int {
+	return len(m.entries)
+}
+
+// MarshalJSON renders the map as a JSON object with keys in insertion
+// order.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, e := range m.entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML renders the map as a YAML mapping node with keys in
+// insertion order, since gopkg.in/yaml.v3 marshals a plain Go map in its
+// randomized iteration order.
+func (m *OrderedMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, e := range m.entries {
+		keyNode := &yaml.Node{}
+		if err := keyNode.Encode(e.Key); err != nil {
+			return nil, err
+		}
+		valNode := &yaml.Node{}
+		if err := valNode.Encode(e.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, keyNode, valNode)
+	}
+	return node, nil
+}
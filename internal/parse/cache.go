@@ -0,0 +1,240 @@
+package parse
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+// defaultMemLimit is the cache's byte budget when the host's total memory
+// can't be determined (e.g. not running on Linux) and
+// NOVOFON_PARSE_MEMLIMIT is unset.
+const defaultMemLimit = 512 * 1024 * 1024
+
+func init() {
+	// APIData.RequestJSON/ResponseJSON hold map[string]interface{} trees
+	// decoded from JSON examples; gob needs their concrete types
+	// registered to encode/decode the interface{} values inside them.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// Stats reports a Cache's cumulative hit/miss/eviction counters and its
+// current in-memory footprint.
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Bytes     int64
+}
+
+// cacheEntry is one Cache slot: the parsed result plus the size of its
+// gob encoding, which is what both bounds the in-memory LRU and gets
+// written to disk.
+type cacheEntry struct {
+	key  string
+	data *models.APIData
+	size int64
+}
+
+// Cache is an LRU cache of parsed *models.APIData keyed by a SHA-256 of
+// the source HTML, bounded by a byte budget rather than an entry count
+// since Novofon doc pages vary widely in size. Entries are additionally
+// persisted under dir (gob-encoded, one file per key) so a fresh process
+// only has to re-parse pages that actually changed.
+type Cache struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	index map[string]*list.Element
+	order *list.List // front = most recently used
+	bytes int64
+
+	hits, misses, evictions int64
+}
+
+// NewCache creates a Cache persisting to dir (pass "" to disable
+// persistence), with a byte budget taken from NOVOFON_PARSE_MEMLIMIT if
+// set, else a quarter of the host's total memory, else defaultMemLimit.
+func NewCache(dir string) *Cache {
+	return &Cache{
+		dir:      dir,
+		maxBytes: memLimit(),
+		index:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// memLimit resolves the cache's byte budget.
+func memLimit() int64 {
+	if v := os.Getenv("NOVOFON_PARSE_MEMLIMIT"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	if total := systemMemoryBytes(); total > 0 {
+		return total / 4
+	}
+	return defaultMemLimit
+}
+
+// systemMemoryBytes returns the host's total physical memory in bytes, or
+// 0 if it can't be determined.
+func systemMemoryBytes() int64 {
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "MemTotal:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return 0
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
+
+// HashHTML returns the cache key for htmlContent.
+func HashHTML(htmlContent string) string {
+	sum := sha256.Sum256([]byte(htmlContent))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached result for key, checking the in-memory LRU first
+// and falling back to disk. Returns false on a full miss.
+func (c *Cache) Get(key string) (*models.APIData, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.index[key]; ok {
+		c.order.MoveToFront(elem)
+		c.hits++
+		return elem.Value.(*cacheEntry).data, true
+	}
+
+	entry, ok := c.loadFromDisk(key)
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.insert(entry)
+	c.hits++
+	return entry.data, true
+}
+
+// Put stores data under key, evicting least-recently-used entries until
+// the cache is back under its byte budget, and persists it to disk.
+func (c *Cache) Put(key string, data *models.APIData) error {
+	if c == nil {
+		return nil
+	}
+
+	encoded, err := encodeEntry(data)
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.index[key]; ok {
+		c.bytes -= elem.Value.(*cacheEntry).size
+		c.order.Remove(elem)
+		delete(c.index, key)
+	}
+	c.insert(&cacheEntry{key: key, data: data, size: int64(len(encoded))})
+	c.mu.Unlock()
+
+	return c.persist(key, encoded)
+}
+
+// insert adds entry to the front of the LRU and evicts from the back
+// until the cache is within maxBytes. Callers must hold c.mu.
+func (c *Cache) insert(entry *cacheEntry) {
+	c.index[entry.key] = c.order.PushFront(entry)
+	c.bytes += entry.size
+
+	for c.bytes > c.maxBytes && c.order.Len() > 1 {
+		back := c.order.Back()
+		victim := back.Value.(*cacheEntry)
+		c.order.Remove(back)
+		delete(c.index, victim.key)
+		c.bytes -= victim.size
+		c.evictions++
+	}
+}
+
+// Stats returns the cache's cumulative counters and current in-memory
+// footprint.
+func (c *Cache) Stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Bytes: c.bytes}
+}
+
+// encodeEntry gob-encodes data for both the in-memory size accounting and
+// on-disk persistence.
+func encodeEntry(data *models.APIData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// persist writes key's gob-encoded entry to dir/<key>.gob. A no-op when
+// the cache has no directory.
+func (c *Cache) persist(key string, encoded []byte) error {
+	if c.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, key+".gob"), encoded, 0644)
+}
+
+// loadFromDisk reads key's persisted entry from dir, if present. Callers
+// must hold c.mu.
+func (c *Cache) loadFromDisk(key string) (*cacheEntry, bool) {
+	if c.dir == "" {
+		return nil, false
+	}
+
+	encoded, err := os.ReadFile(filepath.Join(c.dir, key+".gob"))
+	if err != nil {
+		return nil, false
+	}
+
+	var data models.APIData
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&data); err != nil {
+		return nil, false
+	}
+
+	return &cacheEntry{key: key, data: &data, size: int64(len(encoded))}, true
+}
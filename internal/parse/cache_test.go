@@ -0,0 +1,150 @@
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+func sampleAPIData(name string) *models.APIData {
+	return &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: name},
+	}
+}
+
+func TestCache_GetMissThenPutThenHit(t *testing.T) {
+	c := NewCache("")
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+
+	data := sampleAPIData("get_contacts")
+	if err := c.Put("key1", data); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	got, ok := c.Get("key1")
+	if !ok {
+		t.Fatal("expected a hit after Put")
+	}
+	if got.MethodInfo.Name != "get_contacts" {
+		t.Errorf("expected cached MethodInfo.Name %q, got %q", "get_contacts", got.MethodInfo.Name)
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %+v", stats)
+	}
+}
+
+func TestCache_EvictsLeastRecentlyUsedOverByteBudget(t *testing.T) {
+	c := NewCache("")
+
+	if err := c.Put("a", sampleAPIData("a")); err != nil {
+		t.Fatalf("Put a failed: %v", err)
+	}
+	if err := c.Put("b", sampleAPIData("b")); err != nil {
+		t.Fatalf("Put b failed: %v", err)
+	}
+
+	// Shrink the budget to force eviction on the next insert, below the
+	// test's own setup. Touch "a" first so "b" is the least recently used.
+	c.Get("a")
+	c.maxBytes = c.Stats().Bytes
+
+	if err := c.Put("c", sampleAPIData("c")); err != nil {
+		t.Fatalf("Put c failed: %v", err)
+	}
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to have been evicted as the least recently used entry")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected a to still be present")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Error("expected c to still be present")
+	}
+
+	if stats := c.Stats(); stats.Evictions == 0 {
+		t.Error("expected at least one eviction to be recorded")
+	}
+}
+
+func TestCache_PersistsAndReloadsFromDisk(t *testing.T) {
+	dir := t.TempDir()
+
+	c := NewCache(dir)
+	if err := c.Put("key1", sampleAPIData("get_contacts")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// A fresh Cache over the same dir has nothing in memory, so this only
+	// succeeds if Get falls back to the on-disk entry.
+	reopened := NewCache(dir)
+	got, ok := reopened.Get("key1")
+	if !ok {
+		t.Fatal("expected a hit from the on-disk entry")
+	}
+	if got.MethodInfo.Name != "get_contacts" {
+		t.Errorf("expected reloaded MethodInfo.Name %q, got %q", "get_contacts", got.MethodInfo.Name)
+	}
+}
+
+func TestCache_PutPersistFailureIsNonFatal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("a file blocking a directory path behaves differently on windows")
+	}
+
+	dir := t.TempDir()
+	// A file where the cache expects to create its directory makes
+	// os.MkdirAll fail, simulating a full or read-only cache directory.
+	cacheDir := filepath.Join(dir, "blocked")
+	if err := os.WriteFile(cacheDir, []byte("not a directory"), 0644); err != nil {
+		t.Fatalf("failed to set up blocking file: %v", err)
+	}
+
+	c := NewCache(cacheDir)
+	err := c.Put("key1", sampleAPIData("get_contacts"))
+	if err == nil {
+		t.Fatal("expected Put to report the persist failure")
+	}
+
+	// Despite the persist failure, the entry must still be served from
+	// memory: Put's caller (Parser.ParseHTML) relies on this to treat a
+	// persist error as non-fatal without losing the parsed result.
+	got, ok := c.Get("key1")
+	if !ok {
+		t.Fatal("expected the entry to still be cached in memory despite the persist failure")
+	}
+	if got.MethodInfo.Name != "get_contacts" {
+		t.Errorf("expected in-memory MethodInfo.Name %q, got %q", "get_contacts", got.MethodInfo.Name)
+	}
+}
+
+func TestParseHTML_CachePersistFailureDoesNotFailParse(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("a file blocking a directory path behaves differently on windows")
+	}
+
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "blocked")
+	if err := os.WriteFile(cacheDir, []byte("not a directory"), 0644); err != nil {
+		t.Fatalf("failed to set up blocking file: %v", err)
+	}
+
+	htmlContent := loadTestHTML(t, "start_simple_call.html")
+	parser := NewParser(WithCache(NewCache(cacheDir)))
+
+	apiData, err := parser.ParseHTML(htmlContent)
+	if err != nil {
+		t.Fatalf("expected a cache persist failure not to fail ParseHTML, got: %v", err)
+	}
+	if apiData == nil {
+		t.Fatal("expected a parsed result despite the cache persist failure")
+	}
+}
@@ -0,0 +1,97 @@
+package parse
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/xcono/novofon/internal/models"
+)
+
+// Extractor pulls API documentation data out of a parsed HTML document. A
+// given implementation targets one portal's layout and language (e.g. the
+// Russian or English Novofon docs); Register lets adjacent portals plug in
+// their own without forking Parser.
+type Extractor interface {
+	// Detect reports whether this extractor's selectors match doc, used
+	// by Parser to auto-detect which registered extractor applies when
+	// none was requested explicitly via WithExtractor.
+	Detect(doc *goquery.Document) bool
+
+	ExtractMethodInfo(doc *goquery.Document) (*models.MethodInfo, error)
+	// ExtractRequestParameters returns the request parameters found in
+	// doc, plus the order they appeared in (for callers that need to
+	// preserve HTML order downstream).
+	ExtractRequestParameters(doc *goquery.Document) (map[string]*models.Parameter, []string, error)
+	// ExtractResponseParameters is ExtractRequestParameters for the
+	// response parameters section.
+	ExtractResponseParameters(doc *goquery.Document) (map[string]*models.Parameter, []string, error)
+	ExtractJSONExamples(doc *goquery.Document) (request, response map[string]interface{}, err error)
+	ExtractErrorInformation(doc *goquery.Document) (*models.ErrorInfo, error)
+}
+
+var (
+	registryMu    sync.Mutex
+	registry      = make(map[string]func() Extractor)
+	registryOrder []string
+)
+
+// Register adds an extractor factory under name, so it can be requested
+// explicitly via WithExtractor(name) or picked up by auto-detection. A
+// second Register under an existing name replaces the factory but keeps
+// its place in auto-detection order.
+func Register(name string, factory func() Extractor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[name]; !exists {
+		registryOrder = append(registryOrder, name)
+	}
+	registry[name] = factory
+}
+
+// lookupExtractor returns the factory registered under name, if any.
+func lookupExtractor(name string) (func() Extractor, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// detectExtractor returns a fresh instance of the first registered
+// extractor (in registration order) whose Detect matches doc, or nil if
+// none does.
+func detectExtractor(doc *goquery.Document) Extractor {
+	registryMu.Lock()
+	names := append([]string(nil), registryOrder...)
+	registryMu.Unlock()
+
+	for _, name := range names {
+		factory, ok := lookupExtractor(name)
+		if !ok {
+			continue
+		}
+		if ext := factory(); ext.Detect(doc) {
+			return ext
+		}
+	}
+	return nil
+}
+
+// determineHTTPMethod determines the HTTP method implied by methodName's
+// namespace prefix. The JSON-RPC method naming convention (get./create./
+// update./delete.) isn't locale-specific, so every extractor shares this.
+func determineHTTPMethod(methodName string) string {
+	switch {
+	case strings.HasPrefix(methodName, "get."):
+		return "get"
+	case strings.HasPrefix(methodName, "create."):
+		return "post"
+	case strings.HasPrefix(methodName, "update."):
+		return "put"
+	case strings.HasPrefix(methodName, "delete."):
+		return "delete"
+	default:
+		return "post" // Default for JSON-RPC
+	}
+}
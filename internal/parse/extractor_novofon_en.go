@@ -0,0 +1,253 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/xcono/novofon/internal/models"
+)
+
+func init() {
+	Register("novofon-en", func() Extractor { return &novofonENExtractor{} })
+}
+
+// novofonENExtractor extracts API documentation from the English-language
+// Novofon docs layout: the same table/section structure as
+// novofonRUExtractor, but with English section headers and cell values.
+type novofonENExtractor struct{}
+
+// Detect reports whether doc looks like an English Novofon doc page.
+func (e *novofonENExtractor) Detect(doc *goquery.Document) bool {
+	return doc.Find("th:contains('Method')").Length() > 0
+}
+
+// ExtractMethodInfo extracts basic method information from HTML
+func (e *novofonENExtractor) ExtractMethodInfo(doc *goquery.Document) (*models.MethodInfo, error) {
+	methodInfo := &models.MethodInfo{}
+
+	// Extract method name from table with 'Method' header
+	methodCell := doc.Find("th:contains('Method')")
+	if methodCell.Length() > 0 {
+		parentRow := methodCell.Parent()
+		nextCell := parentRow.Find("th").Next()
+		code := nextCell.Find("code")
+		if code.Length() > 0 {
+			methodInfo.Name = strings.Trim(code.Text(), "\"'")
+		}
+	}
+
+	if methodInfo.Name == "" {
+		return nil, fmt.Errorf("method name not found")
+	}
+
+	// Extract title from h1
+	title := doc.Find("h1").First()
+	if title.Length() > 0 {
+		methodInfo.Title = strings.TrimSpace(title.Text())
+	}
+
+	// Extract description from table
+	descCell := doc.Find("td:contains('Description')")
+	if descCell.Length() > 0 {
+		nextCell := descCell.Next()
+		if nextCell.Length() > 0 {
+			methodInfo.Description = strings.TrimSpace(nextCell.Text())
+		}
+	}
+
+	// Determine HTTP method based on method name
+	methodInfo.HTTPMethod = determineHTTPMethod(methodInfo.Name)
+
+	return methodInfo, nil
+}
+
+// ExtractRequestParameters extracts request parameters from HTML tables
+func (e *novofonENExtractor) ExtractRequestParameters(doc *goquery.Document) (map[string]*models.Parameter, []string, error) {
+	params := make(map[string]*models.Parameter)
+	var order []string
+
+	requestHeader := doc.Find("h4:contains('Request parameters')")
+	if requestHeader.Length() == 0 {
+		return params, order, nil
+	}
+
+	table := requestHeader.Next()
+	if table.Length() == 0 || !table.Is("table") {
+		return params, order, nil
+	}
+
+	table.Find("tr").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			return
+		}
+
+		cells := s.Find("td")
+		if cells.Length() >= 4 {
+			param := parseParameterRowEN(cells, true)
+			if param != nil {
+				if _, exists := params[param.Name]; !exists {
+					order = append(order, param.Name)
+				}
+				params[param.Name] = param
+			}
+		}
+	})
+
+	return params, order, nil
+}
+
+// ExtractResponseParameters extracts response parameters from HTML tables
+func (e *novofonENExtractor) ExtractResponseParameters(doc *goquery.Document) (map[string]*models.Parameter, []string, error) {
+	params := make(map[string]*models.Parameter)
+	var order []string
+
+	responseHeader := doc.Find("h4:contains('Response parameters')")
+	if responseHeader.Length() == 0 {
+		return params, order, nil
+	}
+
+	table := responseHeader.Next()
+	if table.Length() == 0 || !table.Is("table") {
+		return params, order, nil
+	}
+
+	table.Find("tr").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			return
+		}
+
+		cells := s.Find("td")
+		if cells.Length() >= 3 {
+			param := parseParameterRowEN(cells, false)
+			if param != nil {
+				if _, exists := params[param.Name]; !exists {
+					order = append(order, param.Name)
+				}
+				params[param.Name] = param
+			}
+		}
+	})
+
+	return params, order, nil
+}
+
+// ExtractJSONExamples extracts JSON request and response examples
+func (e *novofonENExtractor) ExtractJSONExamples(doc *goquery.Document) (map[string]interface{}, map[string]interface{}, error) {
+	var requestJSON, responseJSON map[string]interface{}
+
+	requestHeader := doc.Find("h4:contains('Example request')")
+	if requestHeader.Length() > 0 {
+		codeBlock := requestHeader.Next()
+		if codeBlock.Is("pre") {
+			code := codeBlock.Find("code")
+			if code.Length() > 0 {
+				jsonStr := code.Text()
+				requestJSON = make(map[string]interface{})
+				if err := json.Unmarshal([]byte(jsonStr), &requestJSON); err != nil {
+					requestJSON = make(map[string]interface{})
+				}
+			}
+		}
+	}
+
+	responseHeader := doc.Find("h4:contains('Example response')")
+	if responseHeader.Length() > 0 {
+		codeBlock := responseHeader.Next()
+		if codeBlock.Is("pre") {
+			code := codeBlock.Find("code")
+			if code.Length() > 0 {
+				jsonStr := code.Text()
+				responseJSON = make(map[string]interface{})
+				if err := json.Unmarshal([]byte(jsonStr), &responseJSON); err != nil {
+					responseJSON = make(map[string]interface{})
+				}
+			}
+		}
+	}
+
+	return requestJSON, responseJSON, nil
+}
+
+// ExtractErrorInformation extracts error information from HTML tables
+func (e *novofonENExtractor) ExtractErrorInformation(doc *goquery.Document) (*models.ErrorInfo, error) {
+	errorInfo := &models.ErrorInfo{
+		Errors: make([]models.Error, 0),
+	}
+
+	errorHeader := doc.Find("h4:contains('Error codes')")
+	if errorHeader.Length() == 0 {
+		return errorInfo, nil
+	}
+
+	table := errorHeader.Next()
+	if table.Length() == 0 || !table.Is("table") {
+		return errorInfo, nil
+	}
+
+	table.Find("tr").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			return
+		}
+
+		cells := s.Find("td")
+		if cells.Length() >= 3 {
+			errorEntry := models.Error{
+				Code:        strings.TrimSpace(cells.Eq(1).Text()),
+				Mnemonic:    strings.TrimSpace(cells.Eq(2).Text()),
+				Description: strings.TrimSpace(cells.Eq(3).Text()),
+			}
+			errorInfo.Errors = append(errorInfo.Errors, errorEntry)
+		}
+	})
+
+	return errorInfo, nil
+}
+
+// parseParameterRowEN is parseParameterRow for the English layout, where
+// the "required" cell reads "yes"/"no" instead of "да"/"нет".
+func parseParameterRowEN(cells *goquery.Selection, isRequest bool) *models.Parameter {
+	if cells.Length() < 3 {
+		return nil
+	}
+
+	param := &models.Parameter{}
+
+	nameCell := cells.Eq(0)
+	nameCode := nameCell.Find("code")
+	if nameCode.Length() > 0 {
+		param.Name = strings.TrimSpace(nameCode.Text())
+	} else {
+		param.Name = strings.TrimSpace(nameCell.Text())
+	}
+
+	if param.Name == "" {
+		return nil
+	}
+
+	typeCell := cells.Eq(1)
+	param.Type = strings.TrimSpace(typeCell.Text())
+
+	if cells.Length() >= 3 {
+		requiredCell := cells.Eq(2)
+		requiredText := strings.ToLower(strings.TrimSpace(requiredCell.Text()))
+		param.Required = requiredText == "yes"
+	}
+
+	if isRequest && cells.Length() >= 5 {
+		allowedValuesCell := cells.Eq(3)
+		param.AllowedValues = strings.TrimSpace(allowedValuesCell.Text())
+
+		descriptionCell := cells.Eq(4)
+		param.Description = strings.TrimSpace(descriptionCell.Text())
+	} else if !isRequest && cells.Length() >= 4 {
+		descriptionCell := cells.Eq(3)
+		param.Description = strings.TrimSpace(descriptionCell.Text())
+	} else if cells.Length() >= 4 {
+		descriptionCell := cells.Eq(cells.Length() - 1)
+		param.Description = strings.TrimSpace(descriptionCell.Text())
+	}
+
+	return param
+}
@@ -0,0 +1,274 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/xcono/novofon/internal/models"
+)
+
+func init() {
+	Register("novofon-ru", func() Extractor { return &novofonRUExtractor{} })
+}
+
+// novofonRUExtractor extracts API documentation from the Russian-language
+// Novofon docs layout: Cyrillic section headers ("Параметры запроса",
+// "Пример ответа", ...) and a fixed table-cell layout per section.
+type novofonRUExtractor struct{}
+
+// Detect reports whether doc looks like a Russian Novofon doc page.
+func (e *novofonRUExtractor) Detect(doc *goquery.Document) bool {
+	return doc.Find("th:contains('Метод')").Length() > 0
+}
+
+// ExtractMethodInfo extracts basic method information from HTML
+func (e *novofonRUExtractor) ExtractMethodInfo(doc *goquery.Document) (*models.MethodInfo, error) {
+	methodInfo := &models.MethodInfo{}
+
+	// Extract method name from table with 'Метод' header
+	methodCell := doc.Find("th:contains('Метод')")
+	if methodCell.Length() > 0 {
+		parentRow := methodCell.Parent()
+		nextCell := parentRow.Find("th").Next()
+		code := nextCell.Find("code")
+		if code.Length() > 0 {
+			methodInfo.Name = strings.Trim(code.Text(), "\"'")
+		}
+	}
+
+	if methodInfo.Name == "" {
+		return nil, fmt.Errorf("method name not found")
+	}
+
+	// Extract title from h1
+	title := doc.Find("h1").First()
+	if title.Length() > 0 {
+		methodInfo.Title = strings.TrimSpace(title.Text())
+	}
+
+	// Extract description from table
+	descCell := doc.Find("td:contains('Описание')")
+	if descCell.Length() > 0 {
+		nextCell := descCell.Next()
+		if nextCell.Length() > 0 {
+			methodInfo.Description = strings.TrimSpace(nextCell.Text())
+		}
+	}
+
+	// Determine HTTP method based on method name
+	methodInfo.HTTPMethod = determineHTTPMethod(methodInfo.Name)
+
+	return methodInfo, nil
+}
+
+// ExtractRequestParameters extracts request parameters from HTML tables
+func (e *novofonRUExtractor) ExtractRequestParameters(doc *goquery.Document) (map[string]*models.Parameter, []string, error) {
+	params := make(map[string]*models.Parameter)
+	var order []string
+
+	// Find the "Параметры запроса" section
+	requestHeader := doc.Find("h4:contains('Параметры запроса')")
+	if requestHeader.Length() == 0 {
+		return params, order, nil // No request parameters section found
+	}
+
+	// Find the table after this header
+	table := requestHeader.Next()
+	if table.Length() == 0 || !table.Is("table") {
+		return params, order, nil
+	}
+
+	// Parse table rows (skip header row)
+	table.Find("tr").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			return // Skip header row
+		}
+
+		cells := s.Find("td")
+		if cells.Length() >= 4 {
+			param := parseParameterRow(cells, true)
+			if param != nil {
+				if _, exists := params[param.Name]; !exists {
+					order = append(order, param.Name)
+				}
+				params[param.Name] = param
+			}
+		}
+	})
+
+	return params, order, nil
+}
+
+// ExtractResponseParameters extracts response parameters from HTML tables
+func (e *novofonRUExtractor) ExtractResponseParameters(doc *goquery.Document) (map[string]*models.Parameter, []string, error) {
+	params := make(map[string]*models.Parameter)
+	var order []string
+
+	// Find the "Параметры ответа" section
+	responseHeader := doc.Find("h4:contains('Параметры ответа')")
+	if responseHeader.Length() == 0 {
+		return params, order, nil // No response parameters section found
+	}
+
+	// Find the table after this header
+	table := responseHeader.Next()
+	if table.Length() == 0 || !table.Is("table") {
+		return params, order, nil
+	}
+
+	// Parse table rows (skip header row)
+	table.Find("tr").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			return // Skip header row
+		}
+
+		cells := s.Find("td")
+		if cells.Length() >= 3 {
+			param := parseParameterRow(cells, false)
+			if param != nil {
+				if _, exists := params[param.Name]; !exists {
+					order = append(order, param.Name)
+				}
+				params[param.Name] = param
+			}
+		}
+	})
+
+	return params, order, nil
+}
+
+// ExtractJSONExamples extracts JSON request and response examples
+func (e *novofonRUExtractor) ExtractJSONExamples(doc *goquery.Document) (map[string]interface{}, map[string]interface{}, error) {
+	var requestJSON, responseJSON map[string]interface{}
+
+	// Find JSON request example
+	requestHeader := doc.Find("h4:contains('Пример запроса')")
+	if requestHeader.Length() > 0 {
+		codeBlock := requestHeader.Next()
+		if codeBlock.Is("pre") {
+			code := codeBlock.Find("code")
+			if code.Length() > 0 {
+				jsonStr := code.Text()
+				requestJSON = make(map[string]interface{})
+				if err := json.Unmarshal([]byte(jsonStr), &requestJSON); err != nil {
+					// If JSON parsing fails, return empty map but don't error
+					requestJSON = make(map[string]interface{})
+				}
+			}
+		}
+	}
+
+	// Find JSON response example
+	responseHeader := doc.Find("h4:contains('Пример ответа')")
+	if responseHeader.Length() > 0 {
+		codeBlock := responseHeader.Next()
+		if codeBlock.Is("pre") {
+			code := codeBlock.Find("code")
+			if code.Length() > 0 {
+				jsonStr := code.Text()
+				responseJSON = make(map[string]interface{})
+				if err := json.Unmarshal([]byte(jsonStr), &responseJSON); err != nil {
+					// If JSON parsing fails, return empty map but don't error
+					responseJSON = make(map[string]interface{})
+				}
+			}
+		}
+	}
+
+	return requestJSON, responseJSON, nil
+}
+
+// ExtractErrorInformation extracts error information from HTML tables
+func (e *novofonRUExtractor) ExtractErrorInformation(doc *goquery.Document) (*models.ErrorInfo, error) {
+	errorInfo := &models.ErrorInfo{
+		Errors: make([]models.Error, 0),
+	}
+
+	// Find the error section
+	errorHeader := doc.Find("h4:contains('Список возвращаемых ошибок')")
+	if errorHeader.Length() == 0 {
+		return errorInfo, nil // No error section found
+	}
+
+	// Find the table after this header
+	table := errorHeader.Next()
+	if table.Length() == 0 || !table.Is("table") {
+		return errorInfo, nil
+	}
+
+	// Parse error rows (skip header row)
+	table.Find("tr").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			return // Skip header row
+		}
+
+		cells := s.Find("td")
+		if cells.Length() >= 3 {
+			errorEntry := models.Error{
+				Code:        strings.TrimSpace(cells.Eq(1).Text()),
+				Mnemonic:    strings.TrimSpace(cells.Eq(2).Text()),
+				Description: strings.TrimSpace(cells.Eq(3).Text()),
+			}
+			errorInfo.Errors = append(errorInfo.Errors, errorEntry)
+		}
+	})
+
+	return errorInfo, nil
+}
+
+// parseParameterRow parses a single parameter row from table cells. It's
+// shared by ExtractRequestParameters/ExtractResponseParameters since both
+// Russian-layout sections use the same cell-ordering conventions.
+func parseParameterRow(cells *goquery.Selection, isRequest bool) *models.Parameter {
+	if cells.Length() < 3 {
+		return nil
+	}
+
+	param := &models.Parameter{}
+
+	// Extract parameter name from first cell
+	nameCell := cells.Eq(0)
+	nameCode := nameCell.Find("code")
+	if nameCode.Length() > 0 {
+		param.Name = strings.TrimSpace(nameCode.Text())
+	} else {
+		param.Name = strings.TrimSpace(nameCell.Text())
+	}
+
+	if param.Name == "" {
+		return nil
+	}
+
+	// Extract type from second cell
+	typeCell := cells.Eq(1)
+	param.Type = strings.TrimSpace(typeCell.Text())
+
+	// Extract required status
+	if cells.Length() >= 3 {
+		requiredCell := cells.Eq(2)
+		requiredText := strings.ToLower(strings.TrimSpace(requiredCell.Text()))
+		param.Required = requiredText == "да"
+	}
+
+	// Extract description and additional information
+	if isRequest && cells.Length() >= 5 {
+		// Request parameters: Name, Type, Required, Allowed Values, Description
+		allowedValuesCell := cells.Eq(3)
+		param.AllowedValues = strings.TrimSpace(allowedValuesCell.Text())
+
+		descriptionCell := cells.Eq(4)
+		param.Description = strings.TrimSpace(descriptionCell.Text())
+	} else if !isRequest && cells.Length() >= 4 {
+		// Response parameters: Name, Type, Required, Description
+		descriptionCell := cells.Eq(3)
+		param.Description = strings.TrimSpace(descriptionCell.Text())
+	} else if cells.Length() >= 4 {
+		// Fallback: assume description is in the last cell
+		descriptionCell := cells.Eq(cells.Length() - 1)
+		param.Description = strings.TrimSpace(descriptionCell.Text())
+	}
+
+	return param
+}
@@ -0,0 +1,29 @@
+package parse
+
+import "testing"
+
+func TestDetectExtractorPicksNovofonEN(t *testing.T) {
+	htmlContent := `<html><body><table><tr><th>Method</th><th><code>"start.simple_call"</code></th></tr></table></body></html>`
+	doc := parseHTML(htmlContent)
+
+	ext := detectExtractor(doc)
+	if ext == nil {
+		t.Fatal("detectExtractor returned nil, expected novofon-en to match")
+	}
+	if _, ok := ext.(*novofonENExtractor); !ok {
+		t.Fatalf("detectExtractor picked %T, expected *novofonENExtractor", ext)
+	}
+}
+
+func TestWithExtractorOverridesAutoDetect(t *testing.T) {
+	htmlContent := loadTestHTML(t, "start_simple_call.html")
+
+	parser := NewParser(WithExtractor("novofon-ru"))
+	apiData, err := parser.ParseHTML(htmlContent)
+	if err != nil {
+		t.Fatalf("ParseHTML returned error: %v", err)
+	}
+	if apiData.MethodInfo.Name != "start.simple_call" {
+		t.Errorf("expected method name 'start.simple_call', got %q", apiData.MethodInfo.Name)
+	}
+}
@@ -0,0 +1,57 @@
+package parse
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xcono/novofon/internal/difftest"
+)
+
+// update rewrites every golden file a test touches instead of comparing
+// against it. Run with: go test ./internal/parse/... -update
+var update = flag.Bool("update", false, "update golden files in testdata/golden")
+
+// assertGolden marshals got to canonical indented JSON and compares it
+// against testdata/golden/<name>.json, failing with a line-oriented diff
+// on mismatch. This replaces writing a t.Errorf per field: adding a new
+// fixture method just means adding one assertGolden call and running
+// -update once to record its expected output.
+func assertGolden(t *testing.T, name string, got interface{}) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal %s: %v", name, err)
+	}
+	data = append(data, '\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("write golden file %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file %s (run with -update to create it): %v", path, err)
+	}
+
+	if string(data) != string(want) {
+		t.Errorf("%s does not match golden file %s; diff (-want +got):\n%s",
+			name, path, difftest.Unified(splitLines(string(want)), splitLines(string(data)), 3))
+	}
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimRight(s, "\n"), "\n")
+}
@@ -0,0 +1,239 @@
+package parse
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/xcono/novofon/internal/models"
+)
+
+// Parser handles HTML parsing and data extraction. Extraction itself is
+// delegated to an Extractor, chosen explicitly via WithExtractor or
+// auto-detected from the document on first use, so new portal layouts or
+// languages can be supported by registering a new Extractor rather than
+// forking Parser.
+type Parser struct {
+	doc   *goquery.Document
+	cache *Cache
+
+	extractorName string
+	extractor     Extractor
+}
+
+// Option configures a Parser.
+type Option func(*Parser)
+
+// WithCache makes ParseHTML consult cache before parsing, keyed by a
+// SHA-256 of the input HTML, and store newly-parsed results back into it.
+func WithCache(cache *Cache) Option {
+	return func(p *Parser) {
+		p.cache = cache
+	}
+}
+
+// WithExtractor forces Parser to use the extractor registered under name
+// instead of auto-detecting one from the document.
+func WithExtractor(name string) Option {
+	return func(p *Parser) {
+		p.extractorName = name
+	}
+}
+
+// NewParser creates a new parser instance
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Doc returns the current document for debugging
+func (p *Parser) Doc() *goquery.Document {
+	return p.doc
+}
+
+// ParseHTML parses HTML content and extracts API documentation data. If
+// the Parser was created with WithCache, a cache hit is returned without
+// re-parsing, and a miss is parsed once and stored for next time.
+func (p *Parser) ParseHTML(htmlContent string) (*models.APIData, error) {
+	if p.cache == nil {
+		return p.parseHTML(htmlContent)
+	}
+
+	key := HashHTML(htmlContent)
+	if cached, ok := p.cache.Get(key); ok {
+		return cached, nil
+	}
+
+	apiData, err := p.parseHTML(htmlContent)
+	if err != nil {
+		return nil, err
+	}
+	// A persist failure (full or read-only cache dir) only costs a future
+	// re-parse; it shouldn't discard a result we already parsed successfully.
+	if err := p.cache.Put(key, apiData); err != nil {
+		slog.Default().Warn("failed to persist parsed result to cache", "key", key, "error", err)
+	}
+	return apiData, nil
+}
+
+// parseHTML does the actual parse, bypassing the cache.
+func (p *Parser) parseHTML(htmlContent string) (*models.APIData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	p.doc = doc
+	p.extractor = nil
+
+	apiData := &models.APIData{
+		RequestParams:  make(map[string]*models.Parameter),
+		ResponseParams: make(map[string]*models.Parameter),
+	}
+
+	// Extract method information
+	methodInfo, err := p.ExtractMethodInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract method info: %w", err)
+	}
+	apiData.MethodInfo = methodInfo
+
+	// Extract request parameters
+	requestParams, requestOrder, err := p.extractRequestParametersOrdered()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract request parameters: %w", err)
+	}
+	apiData.RequestParams = requestParams
+	apiData.RequestParamOrder = requestOrder
+
+	// Extract response parameters
+	responseParams, responseOrder, err := p.extractResponseParametersOrdered()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract response parameters: %w", err)
+	}
+	apiData.ResponseParams = responseParams
+	apiData.ResponseParamOrder = responseOrder
+
+	// Extract JSON examples
+	requestJSON, responseJSON, err := p.ExtractJSONExamples()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract JSON examples: %w", err)
+	}
+	apiData.RequestJSON = requestJSON
+	apiData.ResponseJSON = responseJSON
+
+	// Extract error information
+	errorInfo, err := p.ExtractErrorInformation()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract error information: %w", err)
+	}
+	apiData.ErrorInfo = errorInfo
+
+	return apiData, nil
+}
+
+// currentExtractor returns the Extractor this Parser should use, resolving
+// and caching it on first use: the one named by WithExtractor if set, else
+// the first registered extractor whose Detect matches p.doc.
+func (p *Parser) currentExtractor() (Extractor, error) {
+	if p.extractor != nil {
+		return p.extractor, nil
+	}
+
+	if p.extractorName != "" {
+		factory, ok := lookupExtractor(p.extractorName)
+		if !ok {
+			return nil, fmt.Errorf("no extractor registered under %q", p.extractorName)
+		}
+		p.extractor = factory()
+		return p.extractor, nil
+	}
+
+	if ext := detectExtractor(p.doc); ext != nil {
+		p.extractor = ext
+		return ext, nil
+	}
+
+	return nil, fmt.Errorf("no registered extractor matched this document")
+}
+
+// ExtractMethodInfo extracts basic method information from HTML
+func (p *Parser) ExtractMethodInfo() (*models.MethodInfo, error) {
+	ext, err := p.currentExtractor()
+	if err != nil {
+		return nil, err
+	}
+	return ext.ExtractMethodInfo(p.doc)
+}
+
+// ExtractRequestParameters extracts request parameters from HTML tables
+func (p *Parser) ExtractRequestParameters() (map[string]*models.Parameter, error) {
+	params, _, err := p.extractRequestParametersOrdered()
+	return params, err
+}
+
+// extractRequestParametersOrdered is ExtractRequestParameters plus the
+// order parameters were discovered in, for callers (ParseHTML) that need
+// to preserve HTML order downstream.
+func (p *Parser) extractRequestParametersOrdered() (map[string]*models.Parameter, []string, error) {
+	ext, err := p.currentExtractor()
+	if err != nil {
+		return nil, nil, err
+	}
+	return ext.ExtractRequestParameters(p.doc)
+}
+
+// ExtractResponseParameters extracts response parameters from HTML tables
+func (p *Parser) ExtractResponseParameters() (map[string]*models.Parameter, error) {
+	params, _, err := p.extractResponseParametersOrdered()
+	return params, err
+}
+
+// extractResponseParametersOrdered is ExtractResponseParameters plus the
+// order parameters were discovered in, for callers (ParseHTML) that need
+// to preserve HTML order downstream.
+func (p *Parser) extractResponseParametersOrdered() (map[string]*models.Parameter, []string, error) {
+	ext, err := p.currentExtractor()
+	if err != nil {
+		return nil, nil, err
+	}
+	return ext.ExtractResponseParameters(p.doc)
+}
+
+// ExtractJSONExamples extracts JSON request and response examples
+func (p *Parser) ExtractJSONExamples() (map[string]interface{}, map[string]interface{}, error) {
+	ext, err := p.currentExtractor()
+	if err != nil {
+		return nil, nil, err
+	}
+	return ext.ExtractJSONExamples(p.doc)
+}
+
+// ExtractErrorInformation extracts error information from HTML tables
+func (p *Parser) ExtractErrorInformation() (*models.ErrorInfo, error) {
+	ext, err := p.currentExtractor()
+	if err != nil {
+		return nil, err
+	}
+	return ext.ExtractErrorInformation(p.doc)
+}
+
+// Validate runs a Validator over data, surfacing the gaps ParseHTML
+// itself treats as non-fatal (missing/unparseable JSON examples,
+// parameters missing from the examples, unrecognized types, incomplete error rows) so
+// a caller can decide whether to fail a run instead of generating code
+// from incomplete data.
+func (p *Parser) Validate(data *models.APIData) []Diagnostic {
+	return NewValidator().Validate(data)
+}
+
+// determineHTTPMethod determines HTTP method based on method name. Kept as
+// a Parser method, delegating to the shared package-level helper every
+// Extractor uses, for callers written before the Extractor refactor.
+func (p *Parser) determineHTTPMethod(methodName string) string {
+	return determineHTTPMethod(methodName)
+}
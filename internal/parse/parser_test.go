@@ -9,42 +9,15 @@ import (
 )
 
 func TestParseHTML(t *testing.T) {
-	// Load test HTML file
 	htmlContent := loadTestHTML(t, "start_simple_call.html")
 
 	parser := NewParser()
 	apiData, err := parser.ParseHTML(htmlContent)
-
-	// Now that we have correct Russian HTML structure, parsing should succeed
 	if err != nil {
 		t.Fatalf("ParseHTML returned error: %v", err)
 	}
 
-	// Test basic structure
-	if apiData.MethodInfo == nil {
-		t.Error("MethodInfo should not be nil")
-	}
-
-	if apiData.RequestParams == nil {
-		t.Error("RequestParams should not be nil")
-	}
-
-	if apiData.ResponseParams == nil {
-		t.Error("ResponseParams should not be nil")
-	}
-
-	// Test that we actually extracted some data
-	if apiData.MethodInfo.Name != "start.simple_call" {
-		t.Errorf("Expected method name 'start.simple_call', got '%s'", apiData.MethodInfo.Name)
-	}
-
-	if len(apiData.RequestParams) == 0 {
-		t.Error("Should have extracted request parameters")
-	}
-
-	if len(apiData.ResponseParams) == 0 {
-		t.Error("Should have extracted response parameters")
-	}
+	assertGolden(t, "parse_html", apiData)
 }
 
 func TestExtractMethodInfo(t *testing.T) {
@@ -53,38 +26,11 @@ func TestExtractMethodInfo(t *testing.T) {
 	parser.doc = parseHTML(htmlContent)
 
 	methodInfo, err := parser.ExtractMethodInfo()
-
-	// Now that we have correct Russian HTML structure, parsing should succeed
 	if err != nil {
 		t.Fatalf("ExtractMethodInfo failed: %v", err)
 	}
 
-	// Test basic structure
-	if methodInfo == nil {
-		t.Error("MethodInfo should not be nil")
-		return
-	}
-
-	// Test that we have some basic fields
-	if methodInfo.Name == "" {
-		t.Error("Method name should not be empty")
-	}
-
-	if methodInfo.Name != "start.simple_call" {
-		t.Errorf("Expected method name 'start.simple_call', got '%s'", methodInfo.Name)
-	}
-
-	if methodInfo.Title != "Start simple call" {
-		t.Errorf("Expected title 'Start simple call', got '%s'", methodInfo.Title)
-	}
-
-	if methodInfo.Description == "" {
-		t.Error("Description should not be empty")
-	}
-
-	if methodInfo.HTTPMethod != "post" {
-		t.Errorf("Expected HTTP method 'post', got '%s'", methodInfo.HTTPMethod)
-	}
+	assertGolden(t, "method_info", methodInfo)
 }
 
 func TestExtractRequestParameters(t *testing.T) {
@@ -93,43 +39,11 @@ func TestExtractRequestParameters(t *testing.T) {
 	parser.doc = parseHTML(htmlContent)
 
 	params, err := parser.ExtractRequestParameters()
-
-	// Now that we have correct Russian HTML structure, parsing should succeed
 	if err != nil {
 		t.Fatalf("ExtractRequestParameters failed: %v", err)
 	}
 
-	// Test basic structure
-	if params == nil {
-		t.Error("RequestParams should not be nil")
-	}
-
-	if len(params) == 0 {
-		t.Error("Should have extracted request parameters")
-	}
-
-	// Test specific parameters
-	if accessToken, exists := params["access_token"]; !exists {
-		t.Error("Should have extracted access_token parameter")
-	} else {
-		if accessToken.Type != "string" {
-			t.Errorf("Expected access_token type 'string', got '%s'", accessToken.Type)
-		}
-		if !accessToken.Required {
-			t.Error("Expected access_token to be required")
-		}
-	}
-
-	if contact, exists := params["contact"]; !exists {
-		t.Error("Should have extracted contact parameter")
-	} else {
-		if contact.Type != "string" {
-			t.Errorf("Expected contact type 'string', got '%s'", contact.Type)
-		}
-		if !contact.Required {
-			t.Error("Expected contact to be required")
-		}
-	}
+	assertGolden(t, "request_params", params)
 }
 
 func TestExtractResponseParameters(t *testing.T) {
@@ -138,32 +52,11 @@ func TestExtractResponseParameters(t *testing.T) {
 	parser.doc = parseHTML(htmlContent)
 
 	params, err := parser.ExtractResponseParameters()
-
-	// Now that we have correct Russian HTML structure, parsing should succeed
 	if err != nil {
 		t.Fatalf("ExtractResponseParameters failed: %v", err)
 	}
 
-	// Test basic structure
-	if params == nil {
-		t.Error("ResponseParams should not be nil")
-	}
-
-	if len(params) == 0 {
-		t.Error("Should have extracted response parameters")
-	}
-
-	// Test specific response parameter
-	if callSessionID, exists := params["call_session_id"]; !exists {
-		t.Error("Should have extracted call_session_id parameter")
-	} else {
-		if callSessionID.Type != "number" {
-			t.Errorf("Expected call_session_id type 'number', got '%s'", callSessionID.Type)
-		}
-		if !callSessionID.Required {
-			t.Error("Expected call_session_id to be required")
-		}
-	}
+	assertGolden(t, "response_params", params)
 }
 
 func TestExtractJSONExamples(t *testing.T) {
@@ -172,52 +65,14 @@ func TestExtractJSONExamples(t *testing.T) {
 	parser.doc = parseHTML(htmlContent)
 
 	requestJSON, responseJSON, err := parser.ExtractJSONExamples()
-
-	// Now that we have correct Russian HTML structure, parsing should succeed
 	if err != nil {
 		t.Fatalf("ExtractJSONExamples failed: %v", err)
 	}
 
-	// Test that we extracted JSON examples
-	if requestJSON == nil {
-		t.Error("RequestJSON should not be nil")
-	} else {
-		if len(requestJSON) == 0 {
-			t.Error("RequestJSON should contain data")
-		}
-		// Check that we have the expected JSON structure
-		if method, exists := requestJSON["method"]; !exists {
-			t.Error("RequestJSON should contain 'method' field")
-		} else if method != "start.simple_call" {
-			t.Errorf("Expected method 'start.simple_call', got '%v'", method)
-		}
-	}
-
-	if responseJSON == nil {
-		t.Error("ResponseJSON should not be nil")
-	} else {
-		if len(responseJSON) == 0 {
-			t.Error("ResponseJSON should contain data")
-		}
-		// Check that we have the expected JSON structure
-		if result, exists := responseJSON["result"]; !exists {
-			t.Error("ResponseJSON should contain 'result' field")
-		} else {
-			resultMap, ok := result.(map[string]interface{})
-			if !ok {
-				t.Error("Result should be a map")
-			} else if data, exists := resultMap["data"]; !exists {
-				t.Error("Result should contain 'data' field")
-			} else {
-				dataMap, ok := data.(map[string]interface{})
-				if !ok {
-					t.Error("Data should be a map")
-				} else if _, exists := dataMap["call_session_id"]; !exists {
-					t.Error("Data should contain 'call_session_id' field")
-				}
-			}
-		}
-	}
+	assertGolden(t, "json_examples", map[string]interface{}{
+		"request":  requestJSON,
+		"response": responseJSON,
+	})
 }
 
 func TestExtractErrorInformation(t *testing.T) {
@@ -226,39 +81,11 @@ func TestExtractErrorInformation(t *testing.T) {
 	parser.doc = parseHTML(htmlContent)
 
 	errorInfo, err := parser.ExtractErrorInformation()
-
-	// Now that we have correct Russian HTML structure, parsing should succeed
 	if err != nil {
 		t.Fatalf("ExtractErrorInformation failed: %v", err)
 	}
 
-	// Test basic structure
-	if errorInfo == nil {
-		t.Error("ErrorInfo should not be nil")
-	}
-
-	if errorInfo.Errors == nil {
-		t.Error("ErrorInfo.Errors should not be nil")
-	}
-
-	if len(errorInfo.Errors) == 0 {
-		t.Error("Should have extracted error information")
-	}
-
-	// Test specific error
-	foundTTS := false
-	for _, err := range errorInfo.Errors {
-		if err.Mnemonic == "tts_text_exceeded" {
-			foundTTS = true
-			if err.Code != "-32602" {
-				t.Errorf("Expected error code '-32602', got '%s'", err.Code)
-			}
-			break
-		}
-	}
-	if !foundTTS {
-		t.Error("Should have found tts_text_exceeded error")
-	}
+	assertGolden(t, "error_info", errorInfo)
 }
 
 func TestDetermineHTTPMethod(t *testing.T) {
@@ -284,7 +111,7 @@ func TestParseParameterRow(t *testing.T) {
 }
 
 // Helper functions
-func loadTestHTML(t *testing.T, filename string) string {
+func loadTestHTML(t testing.TB, filename string) string {
 	// Return embedded test HTML that matches the parser's expectations
 	// This HTML structure uses Russian headers that the parser looks for
 	return `<!DOCTYPE html>
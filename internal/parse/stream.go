@@ -0,0 +1,458 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+// StreamParser extracts the same Russian-language Novofon doc layout
+// Parser's novofon-ru extractor does, but in a single pass over
+// golang.org/x/net/html's token stream instead of building a full
+// goquery.Document first. That trades away Parser's flexibility (CSS
+// selectors, multi-locale auto-detection via the Extractor registry) for
+// far fewer allocations on the largest doc pages, which is what the batch
+// pipeline cares about. Parser remains the default; StreamParser is
+// opt-in via NewStreamParser.
+type StreamParser struct{}
+
+// NewStreamParser creates a StreamParser.
+func NewStreamParser() *StreamParser {
+	return &StreamParser{}
+}
+
+// ParseHTML walks htmlContent's token stream once, extracting the same
+// *models.APIData Parser.ParseHTML does for the Russian doc layout.
+func (sp *StreamParser) ParseHTML(htmlContent string) (*models.APIData, error) {
+	z := html.NewTokenizer(strings.NewReader(htmlContent))
+
+	methodInfo := &models.MethodInfo{}
+	apiData := &models.APIData{
+		RequestParams:  make(map[string]*models.Parameter),
+		ResponseParams: make(map[string]*models.Parameter),
+	}
+	errorInfo := &models.ErrorInfo{Errors: make([]models.Error, 0)}
+
+	var h1Done bool
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		name, _ := z.TagName()
+		switch string(name) {
+		case "h1":
+			if !h1Done {
+				methodInfo.Title = strings.TrimSpace(collectText(z, "h1"))
+				h1Done = true
+			}
+
+		case "tr":
+			cells := collectRow(z)
+			if name, ok := methodNameFromRow(cells); ok && methodInfo.Name == "" {
+				methodInfo.Name = name
+			}
+			if desc, ok := descriptionFromRow(cells); ok && methodInfo.Description == "" {
+				methodInfo.Description = desc
+			}
+
+		case "h4":
+			heading := strings.TrimSpace(collectText(z, "h4"))
+			switch {
+			case strings.Contains(heading, "Параметры запроса"):
+				if rows := nextSiblingTable(z); rows != nil {
+					apiData.RequestParams, apiData.RequestParamOrder = parametersFromTable(rows, true)
+				}
+			case strings.Contains(heading, "Параметры ответа"):
+				if rows := nextSiblingTable(z); rows != nil {
+					apiData.ResponseParams, apiData.ResponseParamOrder = parametersFromTable(rows, false)
+				}
+			case strings.Contains(heading, "Пример запроса"):
+				if jsonStr, ok := nextSiblingPreCode(z); ok {
+					apiData.RequestJSON = decodeJSONObject(jsonStr)
+				}
+			case strings.Contains(heading, "Пример ответа"):
+				if jsonStr, ok := nextSiblingPreCode(z); ok {
+					apiData.ResponseJSON = decodeJSONObject(jsonStr)
+				}
+			case strings.Contains(heading, "Список возвращаемых ошибок"):
+				if rows := nextSiblingTable(z); rows != nil {
+					errorInfo.Errors = errorsFromTable(rows)
+				}
+			}
+		}
+	}
+
+	if methodInfo.Name == "" {
+		return nil, fmt.Errorf("method name not found")
+	}
+	methodInfo.HTTPMethod = determineHTTPMethod(methodInfo.Name)
+
+	apiData.MethodInfo = methodInfo
+	apiData.ErrorInfo = errorInfo
+	return apiData, nil
+}
+
+// rowCell is one <td>/<th> from a collected <tr>.
+type rowCell struct {
+	tag      string
+	text     string
+	codeText string
+	hasCode  bool
+}
+
+// collectText consumes tokens until the matching close of the most
+// recently opened tagName (accounting for same-name nesting), returning
+// the concatenation of every text token seen along the way - the
+// streaming equivalent of goquery's Selection.Text().
+func collectText(z *html.Tokenizer, tagName string) string {
+	var buf strings.Builder
+	depth := 1
+	for depth > 0 {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.TextToken:
+			buf.Write(z.Text())
+		case html.StartTagToken:
+			if name, _ := z.TagName(); string(name) == tagName {
+				depth++
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == tagName {
+				depth--
+			}
+		}
+	}
+	return buf.String()
+}
+
+// collectCell consumes one <td>/<th> (tagName), returning its full text
+// plus, separately, the concatenated text of its nested <code> elements, if
+// any.
+func collectCell(z *html.Tokenizer, tagName string) (text, codeText string, hasCode bool) {
+	var buf, codeBuf strings.Builder
+	depth := 1
+	codeDepth := 0
+	for depth > 0 {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.TextToken:
+			t := z.Text()
+			buf.Write(t)
+			if codeDepth > 0 {
+				codeBuf.Write(t)
+			}
+		case html.StartTagToken:
+			tag := ""
+			if name, _ := z.TagName(); true {
+				tag = string(name)
+			}
+			switch {
+			case tag == tagName:
+				depth++
+			case tag == "code":
+				codeDepth++
+				hasCode = true
+			}
+		case html.EndTagToken:
+			tag := ""
+			if name, _ := z.TagName(); true {
+				tag = string(name)
+			}
+			switch {
+			case tag == tagName:
+				depth--
+			case tag == "code":
+				codeDepth--
+			}
+		}
+	}
+	return buf.String(), codeBuf.String(), hasCode
+}
+
+// collectRow consumes one <tr>, returning its <td>/<th> cells in order.
+func collectRow(z *html.Tokenizer) []rowCell {
+	var cells []rowCell
+	depth := 1
+	for depth > 0 {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch tag {
+			case "tr":
+				depth++
+			case "td", "th":
+				text, codeText, hasCode := collectCell(z, tag)
+				cells = append(cells, rowCell{tag: tag, text: text, codeText: codeText, hasCode: hasCode})
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "tr" {
+				depth--
+			}
+		}
+	}
+	return cells
+}
+
+// collectTable consumes one <table>, returning every row it contains
+// (including its header row, left to callers to skip, mirroring Parser's
+// "skip the first <tr>" convention).
+func collectTable(z *html.Tokenizer) [][]rowCell {
+	var rows [][]rowCell
+	depth := 1
+	for depth > 0 {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch tag {
+			case "table":
+				depth++
+			case "tr":
+				rows = append(rows, collectRow(z))
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "table" {
+				depth--
+			}
+		}
+	}
+	return rows
+}
+
+// nextSiblingTable consumes tokens up to the next start tag. If that tag
+// is a "table", its rows are collected and returned; otherwise (or on
+// EOF, or the enclosing element closing first) nil is returned - the
+// streaming equivalent of goquery's `header.Next().Is("table")` check.
+func nextSiblingTable(z *html.Tokenizer) [][]rowCell {
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken, html.EndTagToken:
+			return nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			if string(name) != "table" {
+				return nil
+			}
+			return collectTable(z)
+		}
+	}
+}
+
+// nextSiblingPreCode is nextSiblingTable for a <pre><code>...</code></pre>
+// JSON example block.
+func nextSiblingPreCode(z *html.Tokenizer) (string, bool) {
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken, html.EndTagToken:
+			return "", false
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			if string(name) != "pre" {
+				return "", false
+			}
+			return collectPreCode(z)
+		}
+	}
+}
+
+// collectPreCode consumes one <pre>, returning the text of its first
+// nested <code> element.
+func collectPreCode(z *html.Tokenizer) (string, bool) {
+	depth := 1
+	var codeText string
+	found := false
+	for depth > 0 {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch {
+			case tag == "pre":
+				depth++
+			case tag == "code" && !found:
+				codeText = collectText(z, "code")
+				found = true
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "pre" {
+				depth--
+			}
+		}
+	}
+	return codeText, found
+}
+
+// filterCells returns row's cells whose tag matches tag, in order - the
+// streaming equivalent of goquery's `s.Find("td")`.
+func filterCells(row []rowCell, tag string) []rowCell {
+	var out []rowCell
+	for _, c := range row {
+		if c.tag == tag {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// cellText returns cells[idx].text, or "" if idx is out of range -
+// goquery's Selection.Eq(idx).Text() never panics on a short selection,
+// so neither should this.
+func cellText(cells []rowCell, idx int) string {
+	if idx < 0 || idx >= len(cells) {
+		return ""
+	}
+	return cells[idx].text
+}
+
+// methodNameFromRow finds a <th> containing "Метод" in row and returns
+// the method name from the following cell's <code>, if any.
+func methodNameFromRow(row []rowCell) (string, bool) {
+	for i, c := range row {
+		if c.tag == "th" && strings.Contains(c.text, "Метод") && i+1 < len(row) && row[i+1].hasCode {
+			return strings.Trim(strings.TrimSpace(row[i+1].codeText), "\"'"), true
+		}
+	}
+	return "", false
+}
+
+// descriptionFromRow finds a cell whose text is "Описание" in row and
+// returns the following cell's text.
+func descriptionFromRow(row []rowCell) (string, bool) {
+	for i, c := range row {
+		if strings.TrimSpace(c.text) == "Описание" && i+1 < len(row) {
+			return strings.TrimSpace(row[i+1].text), true
+		}
+	}
+	return "", false
+}
+
+// parametersFromTable builds the parameter map and discovery order for
+// rows, skipping the header row (index 0), matching
+// extractRequestParametersOrdered/extractResponseParametersOrdered.
+func parametersFromTable(rows [][]rowCell, isRequest bool) (map[string]*models.Parameter, []string) {
+	params := make(map[string]*models.Parameter)
+	var order []string
+
+	minCells := 3
+	if isRequest {
+		minCells = 4
+	}
+
+	for i, row := range rows {
+		if i == 0 {
+			continue
+		}
+		tdCells := filterCells(row, "td")
+		if len(tdCells) < minCells {
+			continue
+		}
+		param := parameterFromCells(tdCells, isRequest)
+		if param == nil {
+			continue
+		}
+		if _, exists := params[param.Name]; !exists {
+			order = append(order, param.Name)
+		}
+		params[param.Name] = param
+	}
+
+	return params, order
+}
+
+// parameterFromCells is parseParameterRow, operating on collected
+// rowCells instead of a goquery.Selection.
+func parameterFromCells(cells []rowCell, isRequest bool) *models.Parameter {
+	if len(cells) < 3 {
+		return nil
+	}
+
+	param := &models.Parameter{}
+
+	if cells[0].hasCode {
+		param.Name = strings.TrimSpace(cells[0].codeText)
+	} else {
+		param.Name = strings.TrimSpace(cells[0].text)
+	}
+	if param.Name == "" {
+		return nil
+	}
+
+	param.Type = strings.TrimSpace(cells[1].text)
+
+	requiredText := strings.ToLower(strings.TrimSpace(cells[2].text))
+	param.Required = requiredText == "да"
+
+	switch {
+	case isRequest && len(cells) >= 5:
+		param.AllowedValues = strings.TrimSpace(cells[3].text)
+		param.Description = strings.TrimSpace(cells[4].text)
+	case !isRequest && len(cells) >= 4:
+		param.Description = strings.TrimSpace(cells[3].text)
+	case len(cells) >= 4:
+		param.Description = strings.TrimSpace(cells[len(cells)-1].text)
+	}
+
+	return param
+}
+
+// errorsFromTable builds the error list from rows, skipping the header
+// row, matching ExtractErrorInformation.
+func errorsFromTable(rows [][]rowCell) []models.Error {
+	var errors []models.Error
+	for i, row := range rows {
+		if i == 0 {
+			continue
+		}
+		tdCells := filterCells(row, "td")
+		if len(tdCells) < 3 {
+			continue
+		}
+		errors = append(errors, models.Error{
+			Code:        strings.TrimSpace(cellText(tdCells, 1)),
+			Mnemonic:    strings.TrimSpace(cellText(tdCells, 2)),
+			Description: strings.TrimSpace(cellText(tdCells, 3)),
+		})
+	}
+	return errors
+}
+
+// decodeJSONObject parses jsonStr as a JSON object, returning an empty
+// map (not an error) on failure, matching ExtractJSONExamples.
+func decodeJSONObject(jsonStr string) map[string]interface{} {
+	result := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
+		return make(map[string]interface{})
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package parse
+
+import "testing"
+
+func TestStreamParserParseHTML(t *testing.T) {
+	htmlContent := loadTestHTML(t, "start_simple_call.html")
+
+	sp := NewStreamParser()
+	apiData, err := sp.ParseHTML(htmlContent)
+	if err != nil {
+		t.Fatalf("ParseHTML returned error: %v", err)
+	}
+
+	assertGolden(t, "parse_html", apiData)
+}
+
+func BenchmarkParserParseHTML(b *testing.B) {
+	htmlContent := loadTestHTML(b, "start_simple_call.html")
+	parser := NewParser()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.ParseHTML(htmlContent); err != nil {
+			b.Fatalf("ParseHTML returned error: %v", err)
+		}
+	}
+}
+
+func BenchmarkStreamParserParseHTML(b *testing.B) {
+	htmlContent := loadTestHTML(b, "start_simple_call.html")
+	sp := NewStreamParser()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := sp.ParseHTML(htmlContent); err != nil {
+			b.Fatalf("ParseHTML returned error: %v", err)
+		}
+	}
+}
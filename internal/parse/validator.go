@@ -0,0 +1,180 @@
+package parse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+// Severity classifies a Diagnostic's impact on downstream generation.
+type Severity int
+
+const (
+	// SeverityWarning flags data that is likely incomplete but won't by
+	// itself break generated code (e.g. an undocumented-but-harmless gap
+	// between a parameter table and its example).
+	SeverityWarning Severity = iota
+	// SeverityError flags data that will produce broken or misleading
+	// generated code if left unaddressed.
+	SeverityError
+)
+
+// String renders sev as it should appear in a report.
+func (sev Severity) String() string {
+	switch sev {
+	case SeverityError:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+// Diagnostic is one finding from Validator.Validate against a parsed
+// APIData. File is left empty by Validate and is the caller's
+// responsibility to fill in when it knows which input produced data (see
+// BatchParser's integration).
+type Diagnostic struct {
+	File     string
+	Field    string
+	Severity Severity
+	Code     string
+	Message  string
+}
+
+// knownParameterTypes are the Type column values Validator accepts. The
+// generate package maps all of them except "integer" to a schema type and
+// leaves any other value untyped, so Validator flags unknown values rather
+// than letting them pass silently.
+var knownParameterTypes = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"enum":    true,
+}
+
+// Validator checks a parsed APIData for the gaps Parser.ParseHTML itself
+// doesn't treat as fatal: unparseable or missing JSON examples,
+// parameters absent from those examples, unrecognized Type values, and
+// incomplete error rows.
+type Validator struct{}
+
+// NewValidator creates a Validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Validate runs every check against data and returns its findings in a
+// fixed, deterministic order (JSON examples, request params, response
+// params, error rows).
+func (v *Validator) Validate(data *models.APIData) []Diagnostic {
+	var diags []Diagnostic
+
+	// The parser stores whole JSON-RPC envelopes: request parameters sit
+	// under "params" and response parameters under "result"."data".
+	requestExample := data.RequestJSON
+	if inner, ok := requestExample["params"].(map[string]interface{}); ok {
+		requestExample = inner
+	}
+	responseExample := data.ResponseJSON
+	if result, ok := responseExample["result"].(map[string]interface{}); ok {
+		responseExample = result
+		if inner, ok := result["data"].(map[string]interface{}); ok {
+			responseExample = inner
+		}
+	}
+
+	diags = append(diags, v.checkJSONExample("request_json", data.RequestParams, data.RequestJSON)...)
+	diags = append(diags, v.checkJSONExample("response_json", data.ResponseParams, data.ResponseJSON)...)
+
+	diags = append(diags, v.checkParams("request_params", data.RequestParams, requestExample)...)
+	diags = append(diags, v.checkParams("response_params", data.ResponseParams, responseExample)...)
+
+	diags = append(diags, v.checkErrors(data.ErrorInfo)...)
+
+	return diags
+}
+
+// checkJSONExample flags a params.T table with no corresponding example
+// object, which happens both when the example was never present and
+// when Parser silently swallowed a JSON parse failure.
+func (v *Validator) checkJSONExample(field string, params map[string]*models.Parameter, example map[string]interface{}) []Diagnostic {
+	if len(params) == 0 || len(example) > 0 {
+		return nil
+	}
+	return []Diagnostic{{
+		Field:    field,
+		Severity: SeverityWarning,
+		Code:     "json_example_empty",
+		Message:  fmt.Sprintf("%s has documented parameters but its example is empty or could not be parsed", field),
+	}}
+}
+
+// checkParams flags params missing from example (required params are
+// errors, optional ones warnings) and params with an unrecognized Type.
+func (v *Validator) checkParams(field string, params map[string]*models.Parameter, example map[string]interface{}) []Diagnostic {
+	var diags []Diagnostic
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		param := params[name]
+		if _, ok := example[name]; !ok {
+			if param.Required {
+				diags = append(diags, Diagnostic{
+					Field:    field + "." + name,
+					Severity: SeverityError,
+					Code:     "required_param_missing_from_example",
+					Message:  fmt.Sprintf("required parameter %q is missing from its example", name),
+				})
+			} else {
+				diags = append(diags, Diagnostic{
+					Field:    field + "." + name,
+					Severity: SeverityWarning,
+					Code:     "param_missing_from_example",
+					Message:  fmt.Sprintf("parameter %q is missing from its example", name),
+				})
+			}
+		}
+
+		if !knownParameterTypes[strings.ToLower(strings.TrimSpace(param.Type))] {
+			diags = append(diags, Diagnostic{
+				Field:    field + "." + name,
+				Severity: SeverityWarning,
+				Code:     "unknown_parameter_type",
+				Message:  fmt.Sprintf("parameter %q has unrecognized type %q", name, param.Type),
+			})
+		}
+	}
+
+	return diags
+}
+
+// checkErrors flags error rows missing the Code or Mnemonic a generator
+// would key a response on.
+func (v *Validator) checkErrors(errorInfo *models.ErrorInfo) []Diagnostic {
+	if errorInfo == nil {
+		return nil
+	}
+
+	var diags []Diagnostic
+	for i, errEntry := range errorInfo.Errors {
+		if errEntry.Code == "" || errEntry.Mnemonic == "" {
+			diags = append(diags, Diagnostic{
+				Field:    fmt.Sprintf("errors[%d]", i),
+				Severity: SeverityError,
+				Code:     "error_missing_code_or_mnemonic",
+				Message:  fmt.Sprintf("error row %d is missing a code or mnemonic", i),
+			})
+		}
+	}
+	return diags
+}
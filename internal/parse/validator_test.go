@@ -0,0 +1,59 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+func TestValidatorFlagsMissingAndUnknownParams(t *testing.T) {
+	data := &models.APIData{
+		RequestParams: map[string]*models.Parameter{
+			"access_token": {Name: "access_token", Type: "string", Required: true},
+			"flag":         {Name: "flag", Type: "tristate", Required: false},
+		},
+		RequestJSON: map[string]interface{}{"flag": true},
+		ErrorInfo: &models.ErrorInfo{
+			Errors: []models.Error{{Code: "", Mnemonic: "missing_code"}},
+		},
+	}
+
+	diags := NewValidator().Validate(data)
+
+	var codes []string
+	for _, d := range diags {
+		codes = append(codes, d.Code)
+	}
+
+	wantCodes := map[string]bool{
+		"required_param_missing_from_example": false,
+		"unknown_parameter_type":              false,
+		"error_missing_code_or_mnemonic":      false,
+	}
+	for _, c := range codes {
+		if _, ok := wantCodes[c]; ok {
+			wantCodes[c] = true
+		}
+	}
+	for code, found := range wantCodes {
+		if !found {
+			t.Errorf("expected a diagnostic with code %q, got %v", code, codes)
+		}
+	}
+}
+
+func TestValidatorCleanDataHasNoDiagnostics(t *testing.T) {
+	data := &models.APIData{
+		RequestParams: map[string]*models.Parameter{
+			"access_token": {Name: "access_token", Type: "string", Required: true},
+		},
+		RequestJSON: map[string]interface{}{"access_token": "token"},
+		ErrorInfo: &models.ErrorInfo{
+			Errors: []models.Error{{Code: "-32602", Mnemonic: "invalid_params"}},
+		},
+	}
+
+	if diags := NewValidator().Validate(data); len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+}
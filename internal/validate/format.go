@@ -0,0 +1,193 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// FormatChecker validates a decoded JSON value against a named format, the
+// same interface gojsonschema.FormatChecker declares; re-exported here so
+// callers registering a custom checker don't need to import gojsonschema
+// themselves.
+type FormatChecker = gojsonschema.FormatChecker
+
+// RegisterFormat wires checker into gojsonschema's global format registry
+// under name, and records name as known so generateParameterSchema will
+// reference it. Registering under an existing name replaces its checker.
+func (v *SchemaValidator) RegisterFormat(name string, checker FormatChecker) {
+	gojsonschema.FormatCheckers.Add(name, checker)
+	v.formats[name] = true
+}
+
+// registerBuiltinFormats wires every format checker the Novofon docs
+// actually describe into a fresh SchemaValidator.
+func (v *SchemaValidator) registerBuiltinFormats() {
+	v.RegisterFormat("e164", e164Checker{})
+	v.RegisterFormat("novofon-datetime", novofonDatetimeChecker{})
+	v.RegisterFormat("iso-duration", isoDurationChecker{})
+	v.RegisterFormat("uuid", uuidChecker{})
+	v.RegisterFormat("call-id", callIDChecker{})
+}
+
+// e164Checker validates an E.164 international phone number, e.g.
+// "+442071838750".
+type e164Checker struct{}
+
+var e164Pattern = regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
+
+// IsFormat implements gojsonschema.FormatChecker.
+func (e164Checker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return false
+	}
+	return e164Pattern.MatchString(s)
+}
+
+// novofonDatetimeChecker validates the "YYYY-MM-DD HH:MM:SS" timestamp
+// format Novofon's docs use, with an optional trailing timezone offset
+// (e.g. "2024-05-01 14:30:00 +0300").
+type novofonDatetimeChecker struct{}
+
+// IsFormat implements gojsonschema.FormatChecker.
+func (novofonDatetimeChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return false
+	}
+	for _, layout := range []string{
+		"2006-01-02 15:04:05 -0700",
+		"2006-01-02 15:04:05",
+	} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isoDurationChecker validates an ISO-8601 duration (e.g. "PT1H30M") by
+// normalizing it into something time.ParseDuration accepts.
+type isoDurationChecker struct{}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+S)?)?$`)
+
+// IsFormat implements gojsonschema.FormatChecker.
+func (isoDurationChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return false
+	}
+	if s == "" || s == "P" || !isoDurationPattern.MatchString(s) {
+		return false
+	}
+
+	normalized, err := normalizeISODuration(s)
+	if err != nil {
+		return false
+	}
+	_, err = time.ParseDuration(normalized)
+	return err == nil
+}
+
+var isoDurationDaysPattern = regexp.MustCompile(`^(\d+)D$`)
+var isoDurationTimePattern = regexp.MustCompile(`(\d+)([HMS])`)
+
+// normalizeISODuration rewrites an ISO-8601 duration like "P1DT2H30M" into
+// Go's duration syntax ("1d2h30m" isn't valid Go syntax, so a day count is
+// expanded to literal hours): "24h2h30m".
+func normalizeISODuration(s string) (string, error) {
+	s = strings.TrimPrefix(s, "P")
+
+	var datePart, timePart string
+	if idx := strings.Index(s, "T"); idx >= 0 {
+		datePart, timePart = s[:idx], s[idx+1:]
+	} else {
+		datePart = s
+	}
+
+	var out strings.Builder
+	if datePart != "" {
+		m := isoDurationDaysPattern.FindStringSubmatch(datePart)
+		if m == nil {
+			return "", fmt.Errorf("unrecognized date part %q", datePart)
+		}
+		days := 0
+		for _, c := range m[1] {
+			days = days*10 + int(c-'0')
+		}
+		out.WriteString(strings.Repeat("24h", days))
+	}
+	for _, m := range isoDurationTimePattern.FindAllStringSubmatch(timePart, -1) {
+		out.WriteString(m[1])
+		switch m[2] {
+		case "H":
+			out.WriteString("h")
+		case "M":
+			out.WriteString("m")
+		case "S":
+			out.WriteString("s")
+		}
+	}
+
+	return out.String(), nil
+}
+
+// uuidChecker validates a canonical 8-4-4-4-12 hex UUID.
+type uuidChecker struct{}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsFormat implements gojsonschema.FormatChecker.
+func (uuidChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return false
+	}
+	return uuidPattern.MatchString(s)
+}
+
+// callIDChecker validates a Novofon call ID: a UUID, optionally suffixed
+// with a leg marker (e.g. "...-1", "...-2") the docs use to distinguish
+// call legs.
+type callIDChecker struct{}
+
+var callIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}(-\d+)?$`)
+
+// IsFormat implements gojsonschema.FormatChecker.
+func (callIDChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return false
+	}
+	return callIDPattern.MatchString(s)
+}
+
+// formatNameFor maps an AllowedValues free-text hint (often a mix of
+// English and Russian prose pulled straight from the docs) onto one of our
+// registered format names, instead of dumping the raw hint into the
+// schema's "format" keyword where it wouldn't be a valid JSON-Schema
+// format. Returns ok=false when nothing matches, so the caller falls back
+// to enum parsing.
+func formatNameFor(allowedValues string) (name string, ok bool) {
+	lower := strings.ToLower(allowedValues)
+
+	switch {
+	case strings.Contains(lower, "e.164"), strings.Contains(lower, "international"):
+		return "e164", true
+	case strings.Contains(lower, "uuid"):
+		return "uuid", true
+	case strings.Contains(lower, "call-id"), strings.Contains(lower, "call id"):
+		return "call-id", true
+	case strings.Contains(lower, "duration"), strings.Contains(lower, "iso 8601"), strings.Contains(lower, "iso-8601"):
+		return "iso-duration", true
+	case strings.Contains(lower, "yyyy-mm-dd"), strings.Contains(lower, "гггг-мм-дд"), strings.Contains(lower, "дата"):
+		return "novofon-datetime", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,95 @@
+package validate
+
+import "testing"
+
+func TestBuiltinFormatCheckers(t *testing.T) {
+	tests := []struct {
+		name    string
+		checker FormatChecker
+		valid   []string
+		invalid []string
+	}{
+		{
+			name:    "e164",
+			checker: e164Checker{},
+			valid:   []string{"+442071838750", "79991234567"},
+			invalid: []string{"not-a-number", "0123"},
+		},
+		{
+			name:    "novofon-datetime",
+			checker: novofonDatetimeChecker{},
+			valid:   []string{"2024-05-01 14:30:00", "2024-05-01 14:30:00 +0300"},
+			invalid: []string{"2024-05-01", "not a date"},
+		},
+		{
+			name:    "iso-duration",
+			checker: isoDurationChecker{},
+			valid:   []string{"PT1H30M", "P1DT2H", "PT45S"},
+			invalid: []string{"P", "", "1H30M"},
+		},
+		{
+			name:    "uuid",
+			checker: uuidChecker{},
+			valid:   []string{"550e8400-e29b-41d4-a716-446655440000"},
+			invalid: []string{"550e8400-e29b-41d4-a716", "not-a-uuid"},
+		},
+		{
+			name:    "call-id",
+			checker: callIDChecker{},
+			valid:   []string{"550e8400-e29b-41d4-a716-446655440000", "550e8400-e29b-41d4-a716-446655440000-1"},
+			invalid: []string{"550e8400-e29b-41d4-a716-446655440000-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, s := range tt.valid {
+				if !tt.checker.IsFormat(s) {
+					t.Errorf("expected %q to be a valid %s", s, tt.name)
+				}
+			}
+			for _, s := range tt.invalid {
+				if tt.checker.IsFormat(s) {
+					t.Errorf("expected %q to be an invalid %s", s, tt.name)
+				}
+			}
+			if tt.checker.IsFormat(42) {
+				t.Errorf("expected non-string input to be rejected by %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestRegisterFormatTracksName(t *testing.T) {
+	v := NewSchemaValidator()
+	if !v.formats["e164"] {
+		t.Error("expected built-in formats to be registered by NewSchemaValidator")
+	}
+
+	v.RegisterFormat("custom", e164Checker{})
+	if !v.formats["custom"] {
+		t.Error("expected RegisterFormat to record the format name")
+	}
+}
+
+func TestFormatNameFor(t *testing.T) {
+	tests := []struct {
+		allowedValues string
+		wantName      string
+		wantOK        bool
+	}{
+		{"E.164 international phone number", "e164", true},
+		{"UUID v4", "uuid", true},
+		{"call-id string", "call-id", true},
+		{"ISO 8601 duration", "iso-duration", true},
+		{"YYYY-MM-DD HH:MM:SS", "novofon-datetime", true},
+		{"in, out", "", false},
+	}
+
+	for _, tt := range tests {
+		name, ok := formatNameFor(tt.allowedValues)
+		if ok != tt.wantOK || name != tt.wantName {
+			t.Errorf("formatNameFor(%q) = (%q, %v), want (%q, %v)", tt.allowedValues, name, ok, tt.wantName, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/xcono/novofon/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIInfo populates the info block of the document
+// GenerateOpenAPIFromAPIData builds.
+type OpenAPIInfo struct {
+	Title   string
+	Version string
+}
+
+// GenerateOpenAPIFromAPIData aggregates every parsed method into a single
+// OpenAPI 3.1 document with one shared "POST /" JSON-RPC endpoint: the
+// request and response bodies are a oneOf over per-method schemas in
+// components.schemas, keyed "<MethodName>Request"/"<MethodName>Response".
+// Parameter definitions repeated identically across methods are promoted
+// into components.parameters and referenced by $ref, rather than inlined
+// at every use site.
+func (v *SchemaValidator) GenerateOpenAPIFromAPIData(apis []*models.APIData, info OpenAPIInfo) (map[string]interface{}, error) {
+	if len(apis) == 0 {
+		return nil, fmt.Errorf("no API data provided")
+	}
+
+	shared := v.findSharedParameters(apis)
+
+	schemas := make(map[string]interface{})
+	parameters := make(map[string]interface{})
+	for name, sp := range shared {
+		if sp.count <= 1 || !sp.consistent {
+			continue
+		}
+		parameters[name] = map[string]interface{}{
+			"name":     name,
+			"in":       "body",
+			"required": sp.required,
+			"schema":   sp.schema,
+		}
+	}
+
+	methodNames := make([]string, 0, len(apis))
+	byMethod := make(map[string]*models.APIData, len(apis))
+	for _, apiData := range apis {
+		if apiData == nil || apiData.MethodInfo == nil {
+			continue
+		}
+		methodNames = append(methodNames, apiData.MethodInfo.Name)
+		byMethod[apiData.MethodInfo.Name] = apiData
+	}
+	sort.Strings(methodNames)
+
+	requestRefs := make([]interface{}, 0, len(methodNames))
+	responseRefs := make([]interface{}, 0, len(methodNames))
+	for _, name := range methodNames {
+		apiData := byMethod[name]
+
+		schemas[name+"Request"] = v.buildRequestSchema(apiData, shared)
+		requestRefs = append(requestRefs, map[string]interface{}{
+			"$ref": "#/components/schemas/" + name + "Request",
+		})
+
+		schemas[name+"Response"] = v.buildResponseSchema(apiData)
+		responseRefs = append(responseRefs, map[string]interface{}{
+			"$ref": "#/components/schemas/" + name + "Response",
+		})
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   info.Title,
+			"version": info.Version,
+		},
+		"paths": map[string]interface{}{
+			"/": map[string]interface{}{
+				"post": map[string]interface{}{
+					"summary": "Novofon JSON-RPC 2.0 endpoint",
+					"requestBody": map[string]interface{}{
+						"required": true,
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"oneOf": requestRefs},
+							},
+						},
+					},
+					"responses": map[string]interface{}{
+						"200": map[string]interface{}{
+							"description": "JSON-RPC response",
+							"content": map[string]interface{}{
+								"application/json": map[string]interface{}{
+									"schema": map[string]interface{}{"oneOf": responseRefs},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas":    schemas,
+			"parameters": parameters,
+		},
+	}
+
+	return doc, nil
+}
+
+// GenerateOpenAPIYAML is GenerateOpenAPIFromAPIData, marshaled to YAML.
+func (v *SchemaValidator) GenerateOpenAPIYAML(apis []*models.APIData, info OpenAPIInfo) ([]byte, error) {
+	doc, err := v.GenerateOpenAPIFromAPIData(apis, info)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// sharedParameter tracks one parameter name's schema across every method it
+// appears in, so GenerateOpenAPIFromAPIData can tell whether it's safe to
+// promote into a single components.parameters entry.
+type sharedParameter struct {
+	schema      *models.OrderedMap
+	fingerprint string
+	required    bool
+	count       int
+	// consistent stays true only while every occurrence of this parameter
+	// name has fingerprinted identically; a name reused for two different
+	// shapes across methods must stay inlined at each use site.
+	consistent bool
+}
+
+// findSharedParameters fingerprints every request parameter across apis,
+// keyed by name, to find which are safe to promote to components.parameters.
+func (v *SchemaValidator) findSharedParameters(apis []*models.APIData) map[string]*sharedParameter {
+	shared := make(map[string]*sharedParameter)
+
+	for _, apiData := range apis {
+		if apiData == nil {
+			continue
+		}
+		for name, param := range apiData.RequestParams {
+			schema := v.generateParameterSchema(param)
+			fp := schemaFingerprint(schema)
+
+			sp, ok := shared[name]
+			if !ok {
+				shared[name] = &sharedParameter{
+					schema:      schema,
+					fingerprint: fp,
+					required:    param.Required,
+					count:       1,
+					consistent:  true,
+				}
+				continue
+			}
+
+			sp.count++
+			if sp.fingerprint != fp {
+				sp.consistent = false
+			}
+			if !param.Required {
+				sp.required = false
+			}
+		}
+	}
+
+	return shared
+}
+
+// schemaFingerprint renders a generated schema canonically enough to
+// compare two occurrences of "the same" parameter across methods.
+func schemaFingerprint(schema *models.OrderedMap) string {
+	data, _ := json.Marshal(schema)
+	return string(data)
+}
+
+// buildRequestSchema renders apiData as a JSON-RPC request document schema:
+// the envelope plus a "params" object built from RequestParams, promoting
+// any parameter found in shared to a $ref.
+func (v *SchemaValidator) buildRequestSchema(apiData *models.APIData, shared map[string]*sharedParameter) map[string]interface{} {
+	methodInfo := apiData.MethodInfo
+
+	properties := map[string]interface{}{
+		"jsonrpc": map[string]interface{}{
+			"type":  "string",
+			"const": "2.0",
+		},
+		"id": map[string]interface{}{
+			"type": "number",
+		},
+		"method": map[string]interface{}{
+			"type":  "string",
+			"const": methodInfo.Name,
+		},
+	}
+
+	if len(apiData.RequestParams) > 0 {
+		paramsProperties := make(map[string]interface{})
+		var required []string
+
+		for _, name := range sortedParamNames(apiData.RequestParams) {
+			param := apiData.RequestParams[name]
+
+			if sp, ok := shared[name]; ok && sp.count > 1 && sp.consistent {
+				paramsProperties[name] = map[string]interface{}{
+					"$ref": "#/components/parameters/" + name,
+				}
+			} else {
+				paramsProperties[name] = v.generateParameterSchema(param)
+			}
+
+			if param.Required {
+				required = append(required, name)
+			}
+		}
+
+		properties["params"] = map[string]interface{}{
+			"type":       "object",
+			"properties": paramsProperties,
+			"required":   required,
+		}
+	}
+
+	return map[string]interface{}{
+		"type":        "object",
+		"title":       methodInfo.Title,
+		"description": methodInfo.Description,
+		"properties":  properties,
+		"required":    []string{"jsonrpc", "id", "method"},
+	}
+}
+
+// buildResponseSchema renders apiData's ResponseParams as a JSON-RPC
+// "result" object schema.
+func (v *SchemaValidator) buildResponseSchema(apiData *models.APIData) map[string]interface{} {
+	properties := map[string]interface{}{
+		"jsonrpc": map[string]interface{}{
+			"type":  "string",
+			"const": "2.0",
+		},
+		"id": map[string]interface{}{
+			"type": "number",
+		},
+	}
+
+	if len(apiData.ResponseParams) > 0 {
+		resultProperties := make(map[string]interface{})
+		var required []string
+
+		for _, name := range sortedParamNames(apiData.ResponseParams) {
+			param := apiData.ResponseParams[name]
+			resultProperties[name] = v.generateParameterSchema(param)
+			if param.Required {
+				required = append(required, name)
+			}
+		}
+
+		properties["result"] = map[string]interface{}{
+			"type":       "object",
+			"properties": resultProperties,
+			"required":   required,
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"jsonrpc", "id"},
+	}
+}
+
+// sortedParamNames returns params' keys in sorted order, so generated
+// schemas don't reshuffle property order across runs (map iteration order
+// in Go is randomized).
+func sortedParamNames(params map[string]*models.Parameter) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
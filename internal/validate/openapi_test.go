@@ -0,0 +1,126 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+func TestGenerateOpenAPIFromAPIData_EmptyData(t *testing.T) {
+	v := NewSchemaValidator()
+	if _, err := v.GenerateOpenAPIFromAPIData(nil, OpenAPIInfo{Title: "Test"}); err == nil {
+		t.Error("expected an error for an empty API data slice")
+	}
+}
+
+func TestGenerateOpenAPIFromAPIData_SingleEndpoint(t *testing.T) {
+	v := NewSchemaValidator()
+
+	apis := []*models.APIData{
+		{
+			MethodInfo: &models.MethodInfo{Name: "method.one"},
+			RequestParams: map[string]*models.Parameter{
+				"access_token": {Name: "access_token", Type: "string", Required: true},
+			},
+		},
+	}
+
+	doc, err := v.GenerateOpenAPIFromAPIData(apis, OpenAPIInfo{Title: "Test API", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPIFromAPIData returned error: %v", err)
+	}
+
+	if doc["openapi"] != "3.1.0" {
+		t.Errorf("expected openapi 3.1.0, got %v", doc["openapi"])
+	}
+
+	paths := doc["paths"].(map[string]interface{})
+	if _, ok := paths["/"]; !ok {
+		t.Fatal("expected a single shared \"/\" path")
+	}
+	post := paths["/"].(map[string]interface{})["post"].(map[string]interface{})
+	if _, ok := post["requestBody"]; !ok {
+		t.Error("expected the shared endpoint to have a requestBody")
+	}
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if _, ok := schemas["method.oneRequest"]; !ok {
+		t.Error("expected a method.oneRequest schema in components.schemas")
+	}
+	if _, ok := schemas["method.oneResponse"]; !ok {
+		t.Error("expected a method.oneResponse schema in components.schemas")
+	}
+}
+
+func TestGenerateOpenAPIFromAPIData_PromotesConsistentSharedParameter(t *testing.T) {
+	v := NewSchemaValidator()
+
+	makeAPI := func(name string) *models.APIData {
+		return &models.APIData{
+			MethodInfo: &models.MethodInfo{Name: name},
+			RequestParams: map[string]*models.Parameter{
+				"access_token": {Name: "access_token", Type: "string", Required: true, Description: "Session token"},
+			},
+		}
+	}
+
+	doc, err := v.GenerateOpenAPIFromAPIData([]*models.APIData{makeAPI("method.one"), makeAPI("method.two")}, OpenAPIInfo{Title: "Test"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPIFromAPIData returned error: %v", err)
+	}
+
+	parameters := doc["components"].(map[string]interface{})["parameters"].(map[string]interface{})
+	if _, ok := parameters["access_token"]; !ok {
+		t.Fatal("expected access_token to be promoted into components.parameters")
+	}
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	requestSchema := schemas["method.oneRequest"].(map[string]interface{})
+	properties := requestSchema["properties"].(map[string]interface{})
+	params := properties["params"].(map[string]interface{})
+	paramsProperties := params["properties"].(map[string]interface{})
+	accessToken := paramsProperties["access_token"].(map[string]interface{})
+	if accessToken["$ref"] != "#/components/parameters/access_token" {
+		t.Errorf("expected access_token to reference components.parameters, got %v", accessToken)
+	}
+}
+
+func TestGenerateOpenAPIFromAPIData_LeavesInconsistentParameterInline(t *testing.T) {
+	v := NewSchemaValidator()
+
+	apis := []*models.APIData{
+		{
+			MethodInfo: &models.MethodInfo{Name: "method.one"},
+			RequestParams: map[string]*models.Parameter{
+				"value": {Name: "value", Type: "string", Required: true},
+			},
+		},
+		{
+			MethodInfo: &models.MethodInfo{Name: "method.two"},
+			RequestParams: map[string]*models.Parameter{
+				"value": {Name: "value", Type: "number", Required: true},
+			},
+		},
+	}
+
+	doc, err := v.GenerateOpenAPIFromAPIData(apis, OpenAPIInfo{Title: "Test"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPIFromAPIData returned error: %v", err)
+	}
+
+	parameters := doc["components"].(map[string]interface{})["parameters"].(map[string]interface{})
+	if _, ok := parameters["value"]; ok {
+		t.Error("expected a parameter with inconsistent shapes across methods to stay inlined, not promoted")
+	}
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	requestSchema := schemas["method.oneRequest"].(map[string]interface{})
+	properties := requestSchema["properties"].(map[string]interface{})
+	params := properties["params"].(map[string]interface{})
+	paramsProperties := params["properties"].(map[string]interface{})
+	// A promoted parameter is rewritten to a map[string]interface{}{"$ref": ...};
+	// an inlined one stays a *models.OrderedMap built by generateParameterSchema.
+	if _, isRef := paramsProperties["value"].(map[string]interface{}); isRef {
+		t.Error("expected value's schema to be inlined, not a $ref")
+	}
+}
@@ -12,31 +12,136 @@ import (
 // SchemaValidator validates JSON data against JSON schemas
 type SchemaValidator struct {
 	schemas map[string]*gojsonschema.Schema
+	// formats tracks every format name registered via RegisterFormat (built
+	// in or custom), so generateParameterSchema only ever emits a "format"
+	// keyword gojsonschema actually knows how to check.
+	formats map[string]bool
+	// loader accumulates every schema added via AddSchema/AddSchemaFromFile/
+	// AddSchemaFromURL under its schemaRefID, so a schema added later can
+	// $ref an earlier one by name (e.g. "novofon://common#/definitions/Pagination")
+	// without inlining it.
+	loader *gojsonschema.SchemaLoader
+	// definitions holds every schema registered via RegisterDefinition,
+	// keyed by name. GenerateSchemaFromAPIData publishes them under a
+	// top-level "$defs", so generated per-method schemas can $ref shared
+	// fragments (pagination, auth headers, E.164 phone, ISO date) as
+	// "#/$defs/<name>" instead of repeating them inline; ResolveRefs can
+	// later inline those refs for consumers that don't follow $ref.
+	definitions map[string]interface{}
+	// dialect is the "$schema" dialect URI GenerateSchemaFromAPIData
+	// stamps onto its output. Defaults to DialectDraft07.
+	dialect string
+	// preserveOrder controls whether GenerateSchemaFromAPIData emits
+	// "properties" in the order the parser discovered parameters in HTML
+	// (true, the default) or alphabetical map order (false). Preserving
+	// order keeps generated-schema diffs limited to what actually
+	// changed instead of churning on Go's randomized map iteration.
+	preserveOrder bool
 }
 
-// NewSchemaValidator creates a new schema validator
+// JSON Schema dialect URIs accepted by SetDialect.
+const (
+	DialectDraft07      = "http://json-schema.org/draft-07/schema#"
+	DialectDraft2020_12 = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// schemaRefScheme prefixes the name a schema was added under to build the
+// URI other schemas $ref it by.
+const schemaRefScheme = "novofon://"
+
+func schemaRefID(name string) string {
+	return schemaRefScheme + name
+}
+
+// NewSchemaValidator creates a new schema validator, with RegisterFormat
+// already called for every format the Novofon docs describe (e164,
+// novofon-datetime, iso-duration, uuid, call-id).
 func NewSchemaValidator() *SchemaValidator {
-	return &SchemaValidator{
-		schemas: make(map[string]*gojsonschema.Schema),
+	v := &SchemaValidator{
+		schemas:       make(map[string]*gojsonschema.Schema),
+		formats:       make(map[string]bool),
+		loader:        gojsonschema.NewSchemaLoader(),
+		definitions:   make(map[string]interface{}),
+		dialect:       DialectDraft07,
+		preserveOrder: true,
 	}
+	v.registerBuiltinFormats()
+	return v
 }
 
-// AddSchema adds a JSON schema to the validator
-func (v *SchemaValidator) AddSchema(name string, schemaData interface{}) error {
-	var loader gojsonschema.JSONLoader
+// SetPreserveOrder changes whether GenerateSchemaFromAPIData emits
+// "properties" in HTML-discovery order (true, the default) or
+// alphabetical map order (false).
+func (v *SchemaValidator) SetPreserveOrder(preserve bool) {
+	v.preserveOrder = preserve
+}
 
-	switch data := schemaData.(type) {
-	case string:
-		loader = gojsonschema.NewStringLoader(data)
-	case []byte:
-		loader = gojsonschema.NewBytesLoader(data)
-	case map[string]interface{}:
-		loader = gojsonschema.NewGoLoader(data)
-	default:
-		return fmt.Errorf("unsupported schema data type: %T", schemaData)
+// SchemaOption configures how a single AddSchema/AddSchemaFromFile/
+// AddSchemaFromURL call compiles its schema.
+type SchemaOption func(*schemaConfig)
+
+type schemaConfig struct {
+	draft gojsonschema.Draft
+}
+
+// WithDraft pins the JSON-Schema draft a schema is compiled against, e.g.
+// gojsonschema.Draft2019_09. Schemas default to Draft7, matching what
+// GenerateSchemaFromAPIData emits.
+func WithDraft(draft gojsonschema.Draft) SchemaOption {
+	return func(c *schemaConfig) {
+		c.draft = draft
+	}
+}
+
+// AddSchema adds a JSON schema to the validator from an in-memory JSON
+// string, []byte, or map[string]interface{}.
+func (v *SchemaValidator) AddSchema(name string, schemaData interface{}, opts ...SchemaOption) error {
+	loader, err := schemaDataLoader(schemaData)
+	if err != nil {
+		return err
+	}
+	return v.addSchema(name, loader, opts...)
+}
+
+// AddSchemaFromFile adds a JSON schema read from a local file path.
+func (v *SchemaValidator) AddSchemaFromFile(name, path string, opts ...SchemaOption) error {
+	return v.addSchema(name, gojsonschema.NewReferenceLoader("file://"+path), opts...)
+}
+
+// AddSchemaFromURL adds a JSON schema fetched over HTTP(S), so a shared
+// library of component schemas (the common Novofon envelope, pagination,
+// error shapes) can be served and referenced remotely rather than
+// duplicated per method.
+func (v *SchemaValidator) AddSchemaFromURL(name, url string, opts ...SchemaOption) error {
+	return v.addSchema(name, gojsonschema.NewReferenceLoader(url), opts...)
+}
+
+// addSchema registers loader under name in the shared SchemaLoader pool
+// (so later schemas can $ref it by schemaRefID(name)), then compiles it
+// against that same pool so its own $refs resolve against schemas added
+// before it.
+func (v *SchemaValidator) addSchema(name string, loader gojsonschema.JSONLoader, opts ...SchemaOption) error {
+	cfg := &schemaConfig{draft: gojsonschema.Draft7}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Draft is a property of the shared loader rather than of an individual
+	// schema, so pinning it per schema only holds for the compile that
+	// immediately follows.
+	v.loader.Draft = cfg.draft
+
+	if err := v.loader.AddSchema(schemaRefID(name), loader); err != nil {
+		return fmt.Errorf("failed to register schema %s: %w", name, err)
 	}
 
-	schema, err := gojsonschema.NewSchema(loader)
+	// Compile by reference rather than handing Compile the original loader
+	// again: an in-memory loader (NewStringLoader/NewGoLoader) has no
+	// JsonReference of its own, so Compile would re-parse and re-pool the
+	// document under the empty reference, colliding with whatever schema
+	// was added that way before it. Compiling schemaRefID(name) instead
+	// fetches the document AddSchema just pooled above.
+	schema, err := v.loader.Compile(gojsonschema.NewReferenceLoader(schemaRefID(name)))
 	if err != nil {
 		return fmt.Errorf("failed to compile schema %s: %w", name, err)
 	}
@@ -45,6 +150,157 @@ func (v *SchemaValidator) AddSchema(name string, schemaData interface{}) error {
 	return nil
 }
 
+// SetDialect changes the "$schema" dialect URI GenerateSchemaFromAPIData
+// stamps onto its output, e.g. DialectDraft2020_12.
+func (v *SchemaValidator) SetDialect(uri string) {
+	v.dialect = uri
+}
+
+// RegisterDefinition adds schema to the validator's shared $defs registry
+// under name, so any schema GenerateSchemaFromAPIData produces afterwards
+// can reference it as "#/$defs/<name>" instead of repeating it inline.
+func (v *SchemaValidator) RegisterDefinition(name string, schema interface{}) error {
+	def, err := schemaDataMap(schema)
+	if err != nil {
+		return fmt.Errorf("failed to register definition %s: %w", name, err)
+	}
+	v.definitions[name] = def
+	return nil
+}
+
+// ResolveRefs walks schema and returns a copy with every "#/$defs/<name>"
+// $ref inlined from the validator's registered definitions. Refs outside
+// "#/$defs/" (external $id-anchored refs, schemaRefID refs into the shared
+// loader) are left untouched, since those resolve at validation time via
+// gojsonschema's own loader instead. A $ref chain that cycles back on
+// itself is reported as an error rather than recursing forever.
+func (v *SchemaValidator) ResolveRefs(schema map[string]interface{}) (map[string]interface{}, error) {
+	return v.resolveRefs(schema, map[string]bool{})
+}
+
+func (v *SchemaValidator) resolveRefs(node map[string]interface{}, inProgress map[string]bool) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(node))
+	for key, val := range node {
+		resolvedVal, err := v.resolveRefValue(val, inProgress)
+		if err != nil {
+			return nil, err
+		}
+		resolved[key] = resolvedVal
+	}
+
+	ref, ok := resolved["$ref"].(string)
+	if !ok {
+		return resolved, nil
+	}
+	name, ok := defsRefName(ref)
+	if !ok {
+		return resolved, nil
+	}
+
+	if inProgress[name] {
+		return nil, fmt.Errorf("cyclic $ref detected: #/$defs/%s", name)
+	}
+	def, ok := v.definitions[name]
+	if !ok {
+		return nil, fmt.Errorf("undefined $defs reference: %s", name)
+	}
+	defMap, ok := def.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("definition %s is not an object schema", name)
+	}
+
+	inProgress[name] = true
+	resolvedDef, err := v.resolveRefs(defMap, inProgress)
+	delete(inProgress, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sibling keys alongside $ref (valid since 2019-09) take precedence over
+	// the definition they're merged with.
+	merged := make(map[string]interface{}, len(resolvedDef)+len(resolved))
+	for key, val := range resolvedDef {
+		merged[key] = val
+	}
+	for key, val := range resolved {
+		if key == "$ref" {
+			continue
+		}
+		merged[key] = val
+	}
+	return merged, nil
+}
+
+// resolveRefValue recurses into val's nested maps/slices, so $refs
+// anywhere in the tree - not just at the root - are resolved.
+func (v *SchemaValidator) resolveRefValue(val interface{}, inProgress map[string]bool) (interface{}, error) {
+	switch t := val.(type) {
+	case map[string]interface{}:
+		return v.resolveRefs(t, inProgress)
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, item := range t {
+			resolvedItem, err := v.resolveRefValue(item, inProgress)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolvedItem
+		}
+		return out, nil
+	default:
+		return val, nil
+	}
+}
+
+// defsRefName reports the definition name a "#/$defs/<name>" ref points
+// to, or ok=false for any other kind of $ref.
+func defsRefName(ref string) (name string, ok bool) {
+	const prefix = "#/$defs/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, prefix), true
+}
+
+// schemaDataMap converts an in-memory schema value into a
+// map[string]interface{}, for registries (RegisterDefinition) that need to
+// inspect and merge schema content rather than just compile it.
+func schemaDataMap(schema interface{}) (map[string]interface{}, error) {
+	switch data := schema.(type) {
+	case map[string]interface{}:
+		return data, nil
+	case string:
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &m); err != nil {
+			return nil, fmt.Errorf("invalid schema JSON: %w", err)
+		}
+		return m, nil
+	case []byte:
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("invalid schema JSON: %w", err)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unsupported schema data type: %T", schema)
+	}
+}
+
+// schemaDataLoader converts an in-memory schema value into a
+// gojsonschema.JSONLoader.
+func schemaDataLoader(schemaData interface{}) (gojsonschema.JSONLoader, error) {
+	switch data := schemaData.(type) {
+	case string:
+		return gojsonschema.NewStringLoader(data), nil
+	case []byte:
+		return gojsonschema.NewBytesLoader(data), nil
+	case map[string]interface{}:
+		return gojsonschema.NewGoLoader(data), nil
+	default:
+		return nil, fmt.Errorf("unsupported schema data type: %T", schemaData)
+	}
+}
+
 // Validate validates JSON data against a named schema
 func (v *SchemaValidator) Validate(schemaName string, data interface{}) (*ValidationResult, error) {
 	schema, exists := v.schemas[schemaName]
@@ -122,48 +378,56 @@ func (v *SchemaValidator) GenerateSchemaFromAPIData(apiData *models.APIData) (ma
 	}
 
 	schema := map[string]interface{}{
-		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"$schema":     v.dialect,
 		"type":        "object",
 		"title":       apiData.MethodInfo.Title,
 		"description": apiData.MethodInfo.Description,
 	}
 
-	// JSON-RPC structure
-	properties := map[string]interface{}{
-		"jsonrpc": map[string]interface{}{
-			"type":        "string",
-			"description": "JSON-RPC version",
-			"const":       "2.0",
-		},
-		"id": map[string]interface{}{
-			"type":        "number",
-			"description": "Request identifier",
-		},
-		"method": map[string]interface{}{
-			"type":        "string",
-			"description": "Method name",
-			"const":       apiData.MethodInfo.Name,
-		},
+	if len(v.definitions) > 0 {
+		defs := make(map[string]interface{}, len(v.definitions))
+		for name, def := range v.definitions {
+			defs[name] = def
+		}
+		schema["$defs"] = defs
 	}
 
+	// JSON-RPC structure
+	properties := models.NewOrderedMap()
+	properties.Set("jsonrpc", map[string]interface{}{
+		"type":        "string",
+		"description": "JSON-RPC version",
+		"const":       "2.0",
+	})
+	properties.Set("id", map[string]interface{}{
+		"type":        "number",
+		"description": "Request identifier",
+	})
+	properties.Set("method", map[string]interface{}{
+		"type":        "string",
+		"description": "Method name",
+		"const":       apiData.MethodInfo.Name,
+	})
+
 	// Add params schema
 	if len(apiData.RequestParams) > 0 {
-		paramsProperties := make(map[string]interface{})
+		paramsProperties := models.NewOrderedMap()
 		var required []string
 
-		for name, param := range apiData.RequestParams {
+		for _, name := range v.orderedParamNames(apiData.RequestParams, apiData.RequestParamOrder) {
+			param := apiData.RequestParams[name]
 			paramSchema := v.generateParameterSchema(param)
-			paramsProperties[name] = paramSchema
+			paramsProperties.Set(name, paramSchema)
 			if param.Required {
 				required = append(required, name)
 			}
 		}
 
-		properties["params"] = map[string]interface{}{
+		properties.Set("params", map[string]interface{}{
 			"type":       "object",
 			"properties": paramsProperties,
 			"required":   required,
-		}
+		})
 	}
 
 	schema["properties"] = properties
@@ -172,23 +436,38 @@ func (v *SchemaValidator) GenerateSchemaFromAPIData(apiData *models.APIData) (ma
 	return schema, nil
 }
 
-// generateParameterSchema generates a JSON schema for a parameter
-func (v *SchemaValidator) generateParameterSchema(param *models.Parameter) map[string]interface{} {
-	schema := map[string]interface{}{
-		"type":        v.mapParameterType(param.Type),
-		"description": param.Description,
+// orderedParamNames returns params' keys in HTML-discovery order (as
+// recorded by order) when v.preserveOrder is set, falling back to
+// alphabetical order otherwise or when order is missing an entry (e.g.
+// fixtures built by hand that never went through the parser).
+func (v *SchemaValidator) orderedParamNames(params map[string]*models.Parameter, order []string) []string {
+	if !v.preserveOrder || len(order) != len(params) {
+		return sortedParamNames(params)
 	}
+	return order
+}
+
+// generateParameterSchema generates a JSON schema for a parameter. The
+// returned *models.OrderedMap keeps "type"/"description"/"format"/"enum"/
+// "example" in the stable order they're set below, regardless of map
+// iteration order.
+func (v *SchemaValidator) generateParameterSchema(param *models.Parameter) *models.OrderedMap {
+	schema := models.NewOrderedMap()
+	schema.Set("type", v.mapParameterType(param.Type))
+	schema.Set("description", param.Description)
 
 	// Handle allowed values
 	if param.AllowedValues != "" {
-		// Check if it's a format specification
-		if containsFormatSpec(param.AllowedValues) {
-			schema["format"] = param.AllowedValues
+		// Check if it names one of our registered formats (e164, a Novofon
+		// datetime, ...) rather than dumping the raw doc prose - often
+		// Russian - into "format", which isn't a valid JSON-Schema keyword.
+		if name, ok := formatNameFor(param.AllowedValues); ok && v.formats[name] {
+			schema.Set("format", name)
 		} else {
 			// Try to parse as enum values
 			enumValues := parseEnumValues(param.AllowedValues)
 			if len(enumValues) > 0 {
-				schema["enum"] = enumValues
+				schema.Set("enum", enumValues)
 			}
 		}
 	}
@@ -197,12 +476,12 @@ func (v *SchemaValidator) generateParameterSchema(param *models.Parameter) map[s
 	switch param.Type {
 	case "string":
 		if param.AllowedValues == "" {
-			schema["example"] = "example_string"
+			schema.Set("example", "example_string")
 		}
 	case "number":
-		schema["example"] = 123
+		schema.Set("example", 123)
 	case "boolean":
-		schema["example"] = true
+		schema.Set("example", true)
 	}
 
 	return schema
@@ -226,15 +505,6 @@ func (v *SchemaValidator) mapParameterType(paramType string) string {
 	}
 }
 
-// containsFormatSpec checks if the allowed values contain format specifications
-func containsFormatSpec(allowedValues string) bool {
-	lower := strings.ToLower(allowedValues)
-	return strings.Contains(lower, "формат") ||
-		strings.Contains(lower, "format") ||
-		strings.Contains(lower, "e.164") ||
-		strings.Contains(lower, "international")
-}
-
 // parseEnumValues parses comma-separated enum values
 func parseEnumValues(allowedValues string) []string {
 	values := strings.Split(allowedValues, ",")
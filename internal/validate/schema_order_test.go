@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+func paramsPropertyOrder(t *testing.T, schema map[string]interface{}) []string {
+	t.Helper()
+	properties, ok := schema["properties"].(*models.OrderedMap)
+	if !ok {
+		t.Fatalf("expected properties to be an *models.OrderedMap, got %T", schema["properties"])
+	}
+	params, ok := properties.Get("params")
+	if !ok {
+		t.Fatal("expected a params property")
+	}
+	paramsMap := params.(map[string]interface{})
+	return paramsMap["properties"].(*models.OrderedMap).Keys()
+}
+
+func TestGenerateSchemaFromAPIData_PreservesDiscoveryOrder(t *testing.T) {
+	v := NewSchemaValidator()
+
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "test.method", Title: "Test Method"},
+		RequestParams: map[string]*models.Parameter{
+			"zz_last":   {Name: "zz_last", Type: "string"},
+			"aa_first":  {Name: "aa_first", Type: "string"},
+			"mm_middle": {Name: "mm_middle", Type: "string"},
+		},
+		RequestParamOrder: []string{"zz_last", "aa_first", "mm_middle"},
+	}
+
+	schema, err := v.GenerateSchemaFromAPIData(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSchemaFromAPIData failed: %v", err)
+	}
+
+	got := paramsPropertyOrder(t, schema)
+	want := []string{"zz_last", "aa_first", "mm_middle"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected discovery order %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestGenerateSchemaFromAPIData_FallsBackToAlphabeticalWhenOrderMismatched(t *testing.T) {
+	v := NewSchemaValidator()
+
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "test.method", Title: "Test Method"},
+		RequestParams: map[string]*models.Parameter{
+			"zz_last":  {Name: "zz_last", Type: "string"},
+			"aa_first": {Name: "aa_first", Type: "string"},
+		},
+		// Order doesn't match the param count, e.g. a hand-built fixture
+		// that never went through the parser.
+		RequestParamOrder: []string{"zz_last"},
+	}
+
+	schema, err := v.GenerateSchemaFromAPIData(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSchemaFromAPIData failed: %v", err)
+	}
+
+	got := paramsPropertyOrder(t, schema)
+	want := []string{"aa_first", "zz_last"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected alphabetical fallback %v, got %v", want, got)
+	}
+}
+
+func TestGenerateSchemaFromAPIData_AlphabeticalWhenPreserveOrderDisabled(t *testing.T) {
+	v := NewSchemaValidator()
+	v.SetPreserveOrder(false)
+
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "test.method", Title: "Test Method"},
+		RequestParams: map[string]*models.Parameter{
+			"zz_last":  {Name: "zz_last", Type: "string"},
+			"aa_first": {Name: "aa_first", Type: "string"},
+		},
+		RequestParamOrder: []string{"zz_last", "aa_first"},
+	}
+
+	schema, err := v.GenerateSchemaFromAPIData(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSchemaFromAPIData failed: %v", err)
+	}
+
+	got := paramsPropertyOrder(t, schema)
+	want := []string{"aa_first", "zz_last"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected alphabetical order with preserveOrder disabled, got %v", got)
+	}
+}
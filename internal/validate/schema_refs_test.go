@@ -0,0 +1,157 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/xcono/novofon/internal/models"
+)
+
+func TestResolveRefsInlinesDefsRef(t *testing.T) {
+	v := NewSchemaValidator()
+
+	if err := v.RegisterDefinition("Pagination", map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"page": map[string]interface{}{"type": "number"},
+		},
+	}); err != nil {
+		t.Fatalf("RegisterDefinition failed: %v", err)
+	}
+
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"pagination": map[string]interface{}{"$ref": "#/$defs/Pagination"},
+		},
+	}
+
+	resolved, err := v.ResolveRefs(schema)
+	if err != nil {
+		t.Fatalf("ResolveRefs failed: %v", err)
+	}
+
+	properties := resolved["properties"].(map[string]interface{})
+	pagination := properties["pagination"].(map[string]interface{})
+	if pagination["$ref"] != nil {
+		t.Error("expected the $ref to be inlined, not left in place")
+	}
+	if pagination["type"] != "object" {
+		t.Errorf("expected the inlined definition's type, got %+v", pagination)
+	}
+}
+
+func TestResolveRefsSiblingKeysOverrideDefinition(t *testing.T) {
+	v := NewSchemaValidator()
+
+	if err := v.RegisterDefinition("Name", map[string]interface{}{
+		"type":        "string",
+		"description": "from the definition",
+	}); err != nil {
+		t.Fatalf("RegisterDefinition failed: %v", err)
+	}
+
+	schema := map[string]interface{}{
+		"$ref":        "#/$defs/Name",
+		"description": "overridden at the use site",
+	}
+
+	resolved, err := v.ResolveRefs(schema)
+	if err != nil {
+		t.Fatalf("ResolveRefs failed: %v", err)
+	}
+
+	if resolved["description"] != "overridden at the use site" {
+		t.Errorf("expected the sibling key to win over the definition, got %+v", resolved["description"])
+	}
+	if resolved["type"] != "string" {
+		t.Errorf("expected the definition's type to still apply, got %+v", resolved["type"])
+	}
+}
+
+func TestResolveRefsDetectsCycle(t *testing.T) {
+	v := NewSchemaValidator()
+
+	if err := v.RegisterDefinition("A", map[string]interface{}{"$ref": "#/$defs/B"}); err != nil {
+		t.Fatalf("RegisterDefinition failed: %v", err)
+	}
+	if err := v.RegisterDefinition("B", map[string]interface{}{"$ref": "#/$defs/A"}); err != nil {
+		t.Fatalf("RegisterDefinition failed: %v", err)
+	}
+
+	_, err := v.ResolveRefs(map[string]interface{}{"$ref": "#/$defs/A"})
+	if err == nil {
+		t.Error("expected a cyclic $ref to return an error instead of recursing forever")
+	}
+}
+
+func TestResolveRefsUndefinedDefinition(t *testing.T) {
+	v := NewSchemaValidator()
+
+	_, err := v.ResolveRefs(map[string]interface{}{"$ref": "#/$defs/DoesNotExist"})
+	if err == nil {
+		t.Error("expected an error for a $ref to an unregistered definition")
+	}
+}
+
+func TestResolveRefsLeavesNonDefsRefUntouched(t *testing.T) {
+	v := NewSchemaValidator()
+
+	schema := map[string]interface{}{"$ref": "novofon://common#/definitions/Pagination"}
+	resolved, err := v.ResolveRefs(schema)
+	if err != nil {
+		t.Fatalf("ResolveRefs failed: %v", err)
+	}
+	if resolved["$ref"] != "novofon://common#/definitions/Pagination" {
+		t.Errorf("expected a non-$defs ref to pass through unchanged, got %+v", resolved["$ref"])
+	}
+}
+
+func TestGenerateSchemaFromAPIData_DialectAndDefs(t *testing.T) {
+	v := NewSchemaValidator()
+	v.SetDialect(DialectDraft2020_12)
+
+	if err := v.RegisterDefinition("Pagination", map[string]interface{}{"type": "object"}); err != nil {
+		t.Fatalf("RegisterDefinition failed: %v", err)
+	}
+
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "test.method", Title: "Test Method"},
+	}
+
+	schema, err := v.GenerateSchemaFromAPIData(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSchemaFromAPIData failed: %v", err)
+	}
+
+	if schema["$schema"] != DialectDraft2020_12 {
+		t.Errorf("expected SetDialect to change the emitted $schema, got %v", schema["$schema"])
+	}
+
+	defs, ok := schema["$defs"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected registered definitions to be published under $defs")
+	}
+	if _, ok := defs["Pagination"]; !ok {
+		t.Error("expected the Pagination definition to be present in $defs")
+	}
+}
+
+func TestGenerateSchemaFromAPIData_NoDefsWhenNoneRegistered(t *testing.T) {
+	v := NewSchemaValidator()
+
+	apiData := &models.APIData{
+		MethodInfo: &models.MethodInfo{Name: "test.method", Title: "Test Method"},
+	}
+
+	schema, err := v.GenerateSchemaFromAPIData(apiData)
+	if err != nil {
+		t.Fatalf("GenerateSchemaFromAPIData failed: %v", err)
+	}
+
+	if _, ok := schema["$defs"]; ok {
+		t.Error("expected no $defs key when no definitions were registered")
+	}
+	if schema["$schema"] != DialectDraft07 {
+		t.Errorf("expected the default draft-07 dialect, got %v", schema["$schema"])
+	}
+}
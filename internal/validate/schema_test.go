@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddSchemaResolvesRefAcrossSchemas(t *testing.T) {
+	v := NewSchemaValidator()
+
+	common := map[string]interface{}{
+		"definitions": map[string]interface{}{
+			"Pagination": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"page": map[string]interface{}{"type": "number"},
+				},
+				"required": []string{"page"},
+			},
+		},
+	}
+	if err := v.AddSchema("common", common); err != nil {
+		t.Fatalf("AddSchema(common) failed: %v", err)
+	}
+
+	withRef := map[string]interface{}{
+		"$ref": "novofon://common#/definitions/Pagination",
+	}
+	if err := v.AddSchema("with_ref", withRef); err != nil {
+		t.Fatalf("AddSchema(with_ref) failed: %v", err)
+	}
+
+	result, err := v.Validate("with_ref", map[string]interface{}{"page": 1})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("expected valid data to pass, got errors: %+v", result.Errors)
+	}
+
+	result, err = v.Validate("with_ref", map[string]interface{}{"page": "not a number"})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid {
+		t.Error("expected wrong-typed page to fail validation")
+	}
+}
+
+func TestAddSchemaFromFile(t *testing.T) {
+	v := NewSchemaValidator()
+
+	path := filepath.Join(t.TempDir(), "schema.json")
+	content := `{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write schema file: %v", err)
+	}
+
+	if err := v.AddSchemaFromFile("from_file", path); err != nil {
+		t.Fatalf("AddSchemaFromFile failed: %v", err)
+	}
+
+	result, err := v.Validate("from_file", map[string]interface{}{"name": "test"})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("expected valid data to pass, got errors: %+v", result.Errors)
+	}
+
+	result, err = v.Validate("from_file", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid {
+		t.Error("expected missing required field to fail validation")
+	}
+}
+
+func TestValidateUnknownSchema(t *testing.T) {
+	v := NewSchemaValidator()
+	if _, err := v.Validate("does_not_exist", map[string]interface{}{}); err == nil {
+		t.Error("expected an error for an unregistered schema name")
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xcono/novofon/internal/models"
+	"github.com/xcono/novofon/internal/parse"
+	"github.com/xcono/novofon/internal/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// lintOutcome is the verdict runLintCommand groups a fixture's result
+// under in its summary.
+type lintOutcome string
+
+const (
+	lintValid         lintOutcome = "valid"
+	lintInvalid       lintOutcome = "invalid"
+	lintUnknownMethod lintOutcome = "unknown_method"
+	lintParseError    lintOutcome = "parse_error"
+)
+
+// lintFixtureResult is one fixture file's lint verdict.
+type lintFixtureResult struct {
+	Path    string      `json:"path"`
+	Method  string      `json:"method,omitempty"`
+	Outcome lintOutcome `json:"outcome"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+// runLintCommand implements `novofon lint [-docs=<dir>] [-format=json]
+// <fixtures-dir>`: it validates every *.json/*.yaml fixture under
+// fixtures-dir against the JSON-RPC schema generated from its method's
+// parsed HTML docs, and returns the process exit code - non-zero if any
+// fixture isn't valid, so this can gate CI against schema drift as the
+// upstream docs change.
+func runLintCommand(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	docsDir := fs.String("docs", "input", "directory of HTML docs to parse for reference schemas")
+	format := fs.String("format", "text", "output format: \"text\" or \"json\"")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "Usage: novofon lint [-docs=<dir>] [-format=json] <fixtures-dir>")
+		return 1
+	}
+	fixturesDir := fs.Arg(0)
+
+	methods, err := loadReferenceMethods(*docsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading reference docs: %v\n", err)
+		return 1
+	}
+
+	fixtures, err := findFixtureFiles(fixturesDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning fixtures: %v\n", err)
+		return 1
+	}
+
+	validator := validate.NewSchemaValidator()
+	results := make([]lintFixtureResult, 0, len(fixtures))
+	for _, path := range fixtures {
+		results = append(results, lintFixture(validator, methods, path))
+	}
+
+	if reportLintResults(results, *format) {
+		return 1
+	}
+	return 0
+}
+
+// loadReferenceMethods parses every HTML file under docsDir, keyed by
+// parsed method name, as the reference schemas fixtures are checked
+// against.
+func loadReferenceMethods(docsDir string) (map[string]*models.APIData, error) {
+	htmlFiles, err := findHTMLFiles(docsDir)
+	if err != nil {
+		return nil, fmt.Errorf("find HTML docs in %s: %w", docsDir, err)
+	}
+
+	parser := parse.NewParser()
+	methods := make(map[string]*models.APIData, len(htmlFiles))
+	for _, htmlFile := range htmlFiles {
+		content, err := os.ReadFile(htmlFile)
+		if err != nil {
+			continue
+		}
+		apiData, err := parser.ParseHTML(string(content))
+		if err != nil || apiData == nil || apiData.MethodInfo == nil {
+			continue
+		}
+		methods[apiData.MethodInfo.Name] = apiData
+	}
+	return methods, nil
+}
+
+// findFixtureFiles finds every *.json/*.yaml/*.yml file under dir.
+func findFixtureFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".yaml", ".yml":
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// lintFixture reads, parses, and validates one fixture file against the
+// schema generated for its inferred method.
+func lintFixture(validator *validate.SchemaValidator, methods map[string]*models.APIData, path string) lintFixtureResult {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return lintFixtureResult{Path: path, Outcome: lintParseError, Errors: []string{err.Error()}}
+	}
+
+	doc, err := unmarshalFixture(path, data)
+	if err != nil {
+		return lintFixtureResult{Path: path, Outcome: lintParseError, Errors: []string{err.Error()}}
+	}
+
+	method := inferMethod(path, doc)
+	apiData, ok := methods[method]
+	if !ok {
+		return lintFixtureResult{Path: path, Method: method, Outcome: lintUnknownMethod}
+	}
+
+	schema, err := validator.GenerateSchemaFromAPIData(apiData)
+	if err != nil {
+		return lintFixtureResult{Path: path, Method: method, Outcome: lintParseError, Errors: []string{err.Error()}}
+	}
+
+	// One schema name per fixture file: the validator's shared loader
+	// rejects a second schema added under a name it already holds.
+	schemaName := "fixture_" + method + "_" + shortHash(path)
+	if err := validator.AddSchema(schemaName, schema); err != nil {
+		return lintFixtureResult{Path: path, Method: method, Outcome: lintParseError, Errors: []string{err.Error()}}
+	}
+
+	result, err := validator.Validate(schemaName, doc)
+	if err != nil {
+		return lintFixtureResult{Path: path, Method: method, Outcome: lintParseError, Errors: []string{err.Error()}}
+	}
+	if !result.Valid {
+		errs := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			errs = append(errs, fmt.Sprintf("%s: %s", e.Field, e.Description))
+		}
+		return lintFixtureResult{Path: path, Method: method, Outcome: lintInvalid, Errors: errs}
+	}
+
+	return lintFixtureResult{Path: path, Method: method, Outcome: lintValid}
+}
+
+// unmarshalFixture decodes a fixture file as JSON or YAML, by extension.
+func unmarshalFixture(path string, data []byte) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse YAML fixture: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse JSON fixture: %w", err)
+		}
+	}
+
+	return doc, nil
+}
+
+// inferMethod reads a fixture's top-level "method" field, falling back to
+// the filename convention "<method>.request.json" (or ".response.json").
+func inferMethod(path string, doc map[string]interface{}) string {
+	if m, ok := doc["method"].(string); ok && m != "" {
+		return m
+	}
+
+	base := filepath.Base(path)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	for _, suffix := range []string{".request", ".response"} {
+		base = strings.TrimSuffix(base, suffix)
+	}
+	return base
+}
+
+const (
+	ansiGreen  = "\x1b[32m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+// reportLintResults prints results in the requested format and reports
+// whether any fixture failed (invalid, unknown method, or parse error).
+func reportLintResults(results []lintFixtureResult, format string) bool {
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(results)
+	} else {
+		printLintSummary(results)
+	}
+
+	for _, r := range results {
+		if r.Outcome != lintValid {
+			return true
+		}
+	}
+	return false
+}
+
+// printLintSummary prints a colored, grouped (valid / invalid /
+// unknown-method / parse-error) summary of results.
+func printLintSummary(results []lintFixtureResult) {
+	groups := make(map[lintOutcome][]lintFixtureResult)
+	for _, r := range results {
+		groups[r.Outcome] = append(groups[r.Outcome], r)
+	}
+
+	printLintGroup(groups[lintValid], ansiGreen, "Valid")
+	printLintGroup(groups[lintInvalid], ansiRed, "Invalid")
+	printLintGroup(groups[lintUnknownMethod], ansiYellow, "Unknown method")
+	printLintGroup(groups[lintParseError], ansiRed, "Parse error")
+
+	fmt.Printf("\n%d fixtures: %d valid, %d invalid, %d unknown method, %d parse error\n",
+		len(results), len(groups[lintValid]), len(groups[lintInvalid]),
+		len(groups[lintUnknownMethod]), len(groups[lintParseError]))
+}
+
+func printLintGroup(group []lintFixtureResult, color, label string) {
+	if len(group) == 0 {
+		return
+	}
+
+	fmt.Printf("%s%s (%d)%s\n", color, label, len(group), ansiReset)
+	for _, r := range group {
+		if r.Method != "" {
+			fmt.Printf("  %s [%s]\n", r.Path, r.Method)
+		} else {
+			fmt.Printf("  %s\n", r.Path)
+		}
+		for _, e := range r.Errors {
+			fmt.Printf("    - %s\n", e)
+		}
+	}
+}
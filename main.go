@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/xcono/novofon/internal/compile"
+	"github.com/xcono/novofon/internal/fetch"
 	"github.com/xcono/novofon/internal/generate"
+	"github.com/xcono/novofon/internal/lint"
 	"github.com/xcono/novofon/internal/parse"
 	"gopkg.in/yaml.v3"
 )
@@ -24,18 +30,150 @@ type BundlingConfig struct {
 
 	// Output directory for bundled files
 	OutputDir string
+
+	// OutputPathTemplate overrides the bundled file naming scheme; {domain}
+	// and {api_type} placeholders are substituted. Empty keeps the
+	// historical "{domain}.yaml" / "{domain}_calls.yaml" naming.
+	OutputPathTemplate string
+
+	// IncludeDomains, if non-empty, restricts bundling to only these
+	// domains. ExcludeDomains drops domains even if they'd otherwise match.
+	IncludeDomains []string
+	ExcludeDomains []string
+
+	// Version and Servers override the bundled spec's info.version and
+	// top-level servers list when set.
+	Version string
+	Servers []string
+
+	// Linters run against every generated/bundled spec file before the
+	// process exits; a SeverityError finding from any of them fails the run.
+	Linters []lint.LinterConfig
+
+	// Transforms run, in order, against every bundle this target produces
+	// after it's assembled (e.g. FlattenTransform, RemoveExtensionsTransform).
+	Transforms []Transform
+}
+
+// domainAllowed applies config's include/exclude domain filters.
+func (config BundlingConfig) domainAllowed(domain string) bool {
+	for _, excluded := range config.ExcludeDomains {
+		if excluded == domain {
+			return false
+		}
+	}
+	if len(config.IncludeDomains) == 0 {
+		return true
+	}
+	for _, included := range config.IncludeDomains {
+		if included == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// bundleFileName renders config.OutputPathTemplate (or the historical
+// default) for one domain/apiType combination. version is "latest" or an
+// explicit version pin; it's folded into the file name whenever it isn't
+// "latest", since the historical naming has no version dimension.
+func (config BundlingConfig) bundleFileName(domain string, apiType APIType, version string) string {
+	template := config.OutputPathTemplate
+	if template == "" {
+		if apiType == CallsAPI {
+			template = "{domain}_calls.yaml"
+		} else {
+			template = "{domain}.yaml"
+		}
+	}
+	if version != "" && version != "latest" && !strings.Contains(template, "{version}") {
+		template = strings.TrimSuffix(template, ".yaml") + "@{version}.yaml"
+	}
+	name := strings.ReplaceAll(template, "{domain}", domain)
+	name = strings.ReplaceAll(name, "{api_type}", string(apiType))
+	name = strings.ReplaceAll(name, "{version}", version)
+	return filepath.Join(config.OutputDir, name)
+}
+
+// runLinters builds and runs every configured linter against specPath,
+// printing a summary and returning true if any SeverityError finding fired.
+func runLinters(linters []lint.LinterConfig, specPath string) bool {
+	if len(linters) == 0 {
+		return false
+	}
+
+	built := make([]lint.Linter, 0, len(linters))
+	for _, cfg := range linters {
+		l, err := cfg.Build()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error building linter %s: %v\n", cfg.Type, err)
+			continue
+		}
+		built = append(built, l)
+	}
+
+	findings, err := lint.RunAll(context.Background(), built, specPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running linters on %s: %v\n", specPath, err)
+	}
+	if len(findings) > 0 {
+		fmt.Printf("Lint findings for %s:\n%s", specPath, lint.Summarize(findings))
+	}
+
+	return lint.HasErrors(findings)
+}
+
+// novofonSources describes the documentation trees `-fetch` downloads into
+// inputDir before parsing.
+func novofonSources(inputDir string) []fetch.SourceMetadata {
+	return []fetch.SourceMetadata{
+		{
+			Name:     "novofon",
+			DocURL:   "https://novofon.com/api/",
+			IndexURL: "https://novofon.com/api/index.html",
+			LocalDir: inputDir,
+		},
+	}
 }
 
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <input-dir> <output-dir>\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  input-dir:  Directory containing HTML files to parse\n")
+	// "lint" is a subcommand (mirroring the Woodpecker CLI pattern) rather
+	// than a flag, since it takes a completely different positional
+	// argument and doesn't run the generate-then-bundle pipeline below.
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLintCommand(os.Args[2:]))
+	}
+
+	fetchDocs := flag.Bool("fetch", false, "download the HTML doc tree into input-dir before parsing")
+	fetchOnly := flag.Bool("fetch-only", false, "download the HTML doc tree into input-dir and exit without parsing")
+	targetName := flag.String("target", "all", "novofon.yaml build target to bundle (\"all\" runs every declared target)")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-fetch|-fetch-only] <input-dir> <output-dir>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s lint [-docs=<dir>] [-format=json] <fixtures-dir>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  input-dir:  Directory containing HTML files to parse (or to fetch into)\n")
 		fmt.Fprintf(os.Stderr, "  output-dir: Directory to write OpenAPI YAML files\n")
 		os.Exit(1)
 	}
 
-	inputDir := os.Args[1]
-	outputDir := os.Args[2]
+	inputDir := args[0]
+	outputDir := args[1]
+
+	if *fetchDocs || *fetchOnly {
+		f := fetch.NewFetcher(nil)
+		written, err := f.FetchAll(novofonSources(inputDir))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching docs: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Fetched %d changed pages into %s\n", written, inputDir)
+
+		if *fetchOnly {
+			return
+		}
+	}
 
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -53,8 +191,15 @@ func main() {
 	parser := parse.NewParser()
 	generator := generate.NewOpenAPIGenerator()
 
+	linters := []lint.LinterConfig{
+		{Type: "native"},
+		{Type: "external", Command: "spectral", Args: []string{"lint"}},
+		{Type: "external", Command: "vacuum", Args: []string{"lint"}},
+	}
+
 	processed := 0
 	errors := 0
+	lintFailed := false
 
 	for _, htmlFile := range htmlFiles {
 		fmt.Printf("Processing: %s\n", htmlFile)
@@ -100,6 +245,10 @@ func main() {
 
 		fmt.Printf("Generated: %s\n", outputFile)
 		processed++
+
+		if runLinters(linters, outputFile) {
+			lintFailed = true
+		}
 	}
 
 	fmt.Printf("\nSummary: %d files processed, %d errors\n", processed, errors)
@@ -124,21 +273,32 @@ func main() {
 		apiDir = outputDir // Fallback to original output directory
 	}
 
-	config := BundlingConfig{
-		GroupingStrategy:  "domain", // Use domain-based grouping
-		MinFilesPerDomain: 1,        // Create bundles for any domain with 1+ files
-		DomainMappings: map[string]string{
-			"ca_deal":         "deal",
-			"ca_contact":      "contact",
-			"ca_employee":     "employee",
-			"ca_sales_funnel": "sales_funnel",
-		},
-		OutputDir: apiDir,
+	buildConfig, err := LoadBuildConfig("novofon.yaml")
+	if err != nil {
+		buildConfig = defaultBuildConfig()
 	}
 
-	if err := bundleAPISpecs(outputDir, config); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to bundle API specs: %v\n", err)
-		// Don't fail the entire process for bundling errors
+	targets, err := buildConfig.ResolveTargets(*targetName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving target %q: %v\n", *targetName, err)
+		os.Exit(1)
+	}
+
+	for _, target := range targets {
+		config := target.toBundlingConfig(apiDir, linters)
+		if err := bundleAPISpecs(outputDir, config, target.Input); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to bundle target %q: %v\n", target.Name, err)
+			// Don't fail the entire process for bundling errors, except
+			// a lint failure on a bundled file, which fails the run.
+			if strings.Contains(err.Error(), "lint errors found") {
+				lintFailed = true
+			}
+		}
+	}
+
+	if lintFailed {
+		fmt.Fprintln(os.Stderr, "Lint errors found, see summary above")
+		os.Exit(1)
 	}
 }
 
@@ -306,13 +466,15 @@ func extractDomain(filename string, mappings map[string]string) string {
 }
 
 // bundleAPISpecs combines individual OpenAPI spec files into unified specs
-func bundleAPISpecs(outputDir string, config BundlingConfig) error {
+func bundleAPISpecs(outputDir string, config BundlingConfig, inputGlobs []string) error {
 	// Find all yaml files in the output directory
 	yamlFiles, err := findYAMLFiles(outputDir)
 	if err != nil {
 		return fmt.Errorf("failed to find YAML files: %w", err)
 	}
 
+	yamlFiles = filterByInputGlobs(yamlFiles, inputGlobs)
+
 	if len(yamlFiles) == 0 {
 		return fmt.Errorf("no YAML files found to bundle")
 	}
@@ -322,7 +484,7 @@ func bundleAPISpecs(outputDir string, config BundlingConfig) error {
 	case "domain":
 		return bundleByDomain(yamlFiles, config)
 	case "api_type":
-		return bundleByAPIType(yamlFiles, outputDir)
+		return bundleByAPIType(yamlFiles, outputDir, config)
 	case "none":
 		fmt.Println("Bundling disabled by configuration")
 		return nil
@@ -347,40 +509,136 @@ func bundleByDomain(yamlFiles []string, config BundlingConfig) error {
 
 	// Create bundled files for each domain and API type combination
 	for domain, apiTypeGroups := range domainGroups {
+		if !config.domainAllowed(domain) {
+			fmt.Printf("Skipping domain '%s': excluded by target config\n", domain)
+			continue
+		}
+
 		for apiType, files := range apiTypeGroups {
 			if len(files) < config.MinFilesPerDomain {
 				fmt.Printf("Skipping domain '%s' (%s): only %d files (minimum: %d)\n", domain, apiType, len(files), config.MinFilesPerDomain)
 				continue
 			}
 
-			// Create domain-specific bundled file with API type suffix for calls
-			var bundledFile string
-			var title string
-			var description string
-
+			var title, description string
 			if apiType == CallsAPI {
-				bundledFile = filepath.Join(config.OutputDir, fmt.Sprintf("%s_calls.yaml", domain))
 				title = fmt.Sprintf("Novofon %s Calls API", strings.Title(domain))
 				description = fmt.Sprintf("Combined %s Calls API specifications", strings.Title(domain))
 			} else {
-				bundledFile = filepath.Join(config.OutputDir, fmt.Sprintf("%s.yaml", domain))
 				title = fmt.Sprintf("Novofon %s API", strings.Title(domain))
 				description = fmt.Sprintf("Combined %s API specifications", strings.Title(domain))
 			}
 
-			if err := createBundledSpec(files, bundledFile, title, description); err != nil {
-				return fmt.Errorf("failed to bundle %s %s API specs: %w", domain, apiType, err)
+			if err := bundleVersionedGroup(domain, apiType, files, title, description, config); err != nil {
+				return err
 			}
+		}
+	}
+
+	return nil
+}
+
+// bundleVersionedGroup writes one bundled file per distinct version present
+// among files, plus a "latest" bundle resolving each operation to its
+// newest non-wip version (the same pivot-date rule compile.Resolver uses
+// for explicit version pins).
+func bundleVersionedGroup(domain string, apiType APIType, files []string, title, description string, config BundlingConfig) error {
+	specs := make([]*compile.MethodSpec, 0, len(files))
+	versionSet := map[string]bool{}
+	for _, file := range files {
+		spec, err := compile.LoadMethodSpec(file)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", file, err)
+		}
+		specs = append(specs, spec)
+		if spec.Version != "" {
+			versionSet[spec.Version] = true
+		}
+	}
+
+	pins := make([]string, 0, len(versionSet))
+	for v := range versionSet {
+		pins = append(pins, v)
+	}
+	sort.Strings(pins)
+
+	compiler := compile.NewCompiler(title, description, specs, func(version string) string {
+		return config.bundleFileName(domain, apiType, version)
+	})
+
+	if err := compiler.CompileVersions(pins); err != nil {
+		return fmt.Errorf("compile %s %s versions: %w", domain, apiType, err)
+	}
+	if err := compiler.CompileLatest(); err != nil {
+		return fmt.Errorf("compile %s %s latest: %w", domain, apiType, err)
+	}
 
-			fmt.Printf("Bundled %d %s %s API specs into: %s\n", len(files), domain, apiType, bundledFile)
+	for _, version := range append(pins, "latest") {
+		bundledFile := config.bundleFileName(domain, apiType, version)
+		if _, err := os.Stat(bundledFile); err != nil {
+			continue // this pin had no eligible operations; nothing was written
+		}
+
+		if err := applyBundleOverrides(bundledFile, config.Version, config.Servers, config.Transforms); err != nil {
+			return fmt.Errorf("apply overrides to %s: %w", bundledFile, err)
+		}
+
+		fmt.Printf("Bundled %s %s API specs (%s) into: %s\n", domain, apiType, version, bundledFile)
+
+		if runLinters(config.Linters, bundledFile) {
+			return fmt.Errorf("lint errors found in %s", bundledFile)
 		}
 	}
 
 	return nil
 }
 
+// applyBundleOverrides re-reads a just-written bundle, applies the target's
+// info.version and servers overrides (the same overrides createBundledSpec
+// applies to non-versioned bundles), then runs transforms over the result.
+func applyBundleOverrides(bundledFile, version string, servers []string, transforms []Transform) error {
+	if version == "" && len(servers) == 0 && len(transforms) == 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(bundledFile)
+	if err != nil {
+		return err
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	if version != "" {
+		if info, ok := doc["info"].(map[string]interface{}); ok {
+			info["version"] = version
+		}
+	}
+	if len(servers) > 0 {
+		serverEntries := make([]interface{}, len(servers))
+		for i, url := range servers {
+			serverEntries[i] = map[string]interface{}{"url": url}
+		}
+		doc["servers"] = serverEntries
+	}
+
+	data, err = yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	data, err = applyTransforms(data, transforms)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(bundledFile, data, 0644)
+}
+
 // bundleByAPIType groups files by API type (data vs calls) - original implementation
-func bundleByAPIType(yamlFiles []string, outputDir string) error {
+func bundleByAPIType(yamlFiles []string, outputDir string, config BundlingConfig) error {
 	// Group files by API type (data vs calls)
 	dataFiles := []string{}
 	callFiles := []string{}
@@ -399,10 +657,14 @@ func bundleByAPIType(yamlFiles []string, outputDir string) error {
 		// Place bundled file at the same level as data/ and calls/ directories
 		parentDir := filepath.Dir(outputDir)
 		bundledFile := filepath.Join(parentDir, "data.yaml")
-		if err := createBundledSpec(dataFiles, bundledFile, "Novofon Data API", "Combined Data API specifications"); err != nil {
+		if err := createBundledSpec(dataFiles, bundledFile, "Novofon Data API", "Combined Data API specifications", &BundleOptions{Version: config.Version, Servers: config.Servers, Transforms: config.Transforms}); err != nil {
 			return fmt.Errorf("failed to bundle data API specs: %w", err)
 		}
 		fmt.Printf("Bundled %d Data API specs into: %s\n", len(dataFiles), bundledFile)
+
+		if runLinters(config.Linters, bundledFile) {
+			return fmt.Errorf("lint errors found in %s", bundledFile)
+		}
 	}
 
 	// Bundle call API files - place at top level of outputDir parent
@@ -410,10 +672,14 @@ func bundleByAPIType(yamlFiles []string, outputDir string) error {
 		// Place bundled file at the same level as data/ and calls/ directories
 		parentDir := filepath.Dir(outputDir)
 		bundledFile := filepath.Join(parentDir, "calls.yaml")
-		if err := createBundledSpec(callFiles, bundledFile, "Novofon Call API", "Combined Call API specifications"); err != nil {
+		if err := createBundledSpec(callFiles, bundledFile, "Novofon Call API", "Combined Call API specifications", &BundleOptions{Version: config.Version, Servers: config.Servers, Transforms: config.Transforms}); err != nil {
 			return fmt.Errorf("failed to bundle call API specs: %w", err)
 		}
 		fmt.Printf("Bundled %d Call API specs into: %s\n", len(callFiles), bundledFile)
+
+		if runLinters(config.Linters, bundledFile) {
+			return fmt.Errorf("lint errors found in %s", bundledFile)
+		}
 	}
 
 	return nil
@@ -442,19 +708,50 @@ func findYAMLFiles(dir string) ([]string, error) {
 	return yamlFiles, err
 }
 
+// BundleOptions carries per-target overrides for createBundledSpec that
+// aren't part of its core title/description signature.
+type BundleOptions struct {
+	// Version overrides the bundled spec's info.version (default "1.0.0").
+	Version string
+	// Servers, if non-empty, populates the bundled spec's top-level
+	// servers list.
+	Servers []string
+	// Transforms run, in order, against the bundle after it's assembled.
+	Transforms []Transform
+}
+
 // createBundledSpec creates a single OpenAPI spec from multiple individual specs
-func createBundledSpec(inputFiles []string, outputFile, title, description string) error {
+func createBundledSpec(inputFiles []string, outputFile, title, description string, opts *BundleOptions) error {
+	version := "1.0.0"
+	var servers []string
+	if opts != nil {
+		if opts.Version != "" {
+			version = opts.Version
+		}
+		servers = opts.Servers
+	}
+
 	// Create the base bundled spec structure
 	bundledSpec := map[string]interface{}{
 		"openapi": "3.0.0",
 		"info": map[string]interface{}{
 			"title":       title,
-			"version":     "1.0.0",
+			"version":     version,
 			"description": description,
 		},
 		"paths": make(map[string]interface{}),
 	}
 
+	if len(servers) > 0 {
+		serverEntries := make([]interface{}, len(servers))
+		for i, url := range servers {
+			serverEntries[i] = map[string]interface{}{"url": url}
+		}
+		bundledSpec["servers"] = serverEntries
+	}
+
+	report := newRefMergeReport()
+
 	// Process each input file
 	for _, inputFile := range inputFiles {
 		content, err := os.ReadFile(inputFile)
@@ -469,40 +766,9 @@ func createBundledSpec(inputFiles []string, outputFile, title, description strin
 			continue
 		}
 
-		// Merge paths from this spec into the bundled spec
-		if paths, ok := spec["paths"].(map[string]interface{}); ok {
-			bundledPaths := bundledSpec["paths"].(map[string]interface{})
-			for path, pathItem := range paths {
-				if _, exists := bundledPaths[path]; exists {
-					fmt.Fprintf(os.Stderr, "Warning: Path %s already exists, skipping from %s\n", path, inputFile)
-					continue
-				}
-				bundledPaths[path] = pathItem
-			}
-		}
-
-		// Merge components if they exist
-		if components, ok := spec["components"].(map[string]interface{}); ok {
-			if bundledSpec["components"] == nil {
-				bundledSpec["components"] = make(map[string]interface{})
-			}
-			bundledComponents := bundledSpec["components"].(map[string]interface{})
-
-			for componentType, componentData := range components {
-				if bundledComponents[componentType] == nil {
-					bundledComponents[componentType] = make(map[string]interface{})
-				}
-				targetComponents := bundledComponents[componentType].(map[string]interface{})
-
-				if sourceComponents, ok := componentData.(map[string]interface{}); ok {
-					for name, component := range sourceComponents {
-						if _, exists := targetComponents[name]; !exists {
-							targetComponents[name] = component
-						}
-					}
-				}
-			}
-		}
+		// Reference-aware merge: renames colliding components, rewrites the
+		// $refs that pointed at them, and leaves cyclic $ref chains intact.
+		mergeSpecIntoBundle(bundledSpec, spec, inputFile, report)
 
 		// Merge x-errors if they exist
 		if xerrors, ok := spec["x-errors"]; ok {
@@ -527,12 +793,26 @@ func createBundledSpec(inputFiles []string, outputFile, title, description strin
 		}
 	}
 
+	for source, newName := range report.Renames {
+		fmt.Printf("Renamed %s -> %s to avoid a components collision\n", source, newName)
+	}
+	for _, cycle := range report.Cycles {
+		fmt.Printf("Detected $ref cycle, left intact: %s\n", cycle)
+	}
+
 	// Write the bundled spec
 	bundledContent, err := yaml.Marshal(bundledSpec)
 	if err != nil {
 		return fmt.Errorf("failed to marshal bundled spec: %w", err)
 	}
 
+	if opts != nil && len(opts.Transforms) > 0 {
+		bundledContent, err = applyTransforms(bundledContent, opts.Transforms)
+		if err != nil {
+			return fmt.Errorf("failed to transform bundled spec: %w", err)
+		}
+	}
+
 	if err := os.WriteFile(outputFile, bundledContent, 0644); err != nil {
 		return fmt.Errorf("failed to write bundled spec: %w", err)
 	}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// refSections lists every top-level components.* section (plus the shape of
+// other $ref-bearing collections) that can hold independently named,
+// independently collidable objects when two source specs are bundled.
+var refSections = []string{
+	"schemas", "parameters", "requestBodies", "responses", "headers",
+	"examples", "securitySchemes",
+}
+
+// RefMergeReport records every rename the merger performed and every $ref
+// cycle it had to leave intact, so bundling runs are auditable.
+type RefMergeReport struct {
+	// Renames maps "section/oldName" (scoped to one source file) to the
+	// bundle-local name it was renamed to.
+	Renames map[string]string
+	// Cycles lists every $ref chain in which a name was revisited while
+	// still being resolved.
+	Cycles []string
+}
+
+func newRefMergeReport() *RefMergeReport {
+	return &RefMergeReport{Renames: make(map[string]string)}
+}
+
+// nodeState tracks a ref's position in the white/grey/black DFS used for
+// cycle detection: white (unvisited), grey (on the current resolution
+// stack), black (fully resolved).
+type nodeState int
+
+const (
+	stateWhite nodeState = iota
+	stateGrey
+	stateBlack
+)
+
+// mergeSpecIntoBundle reference-aware-merges spec (freshly parsed from
+// sourceFile) into bundled: every components.* entry is renamed to a
+// bundle-local, collision-free name when a different schema under the same
+// name already exists in bundled, every $ref in spec's own paths/components
+// is rewritten to match, and cyclic $ref chains are detected and left alone
+// rather than expanded.
+func mergeSpecIntoBundle(bundled, spec map[string]interface{}, sourceFile string, report *RefMergeReport) {
+	rename := computeRenames(bundled, spec, sourceFile, report)
+	rewriteRefs(spec, rename)
+	detectRefCycles(spec, report)
+	mergeComponents(bundled, spec, rename)
+	mergePaths(bundled, spec, sourceFile)
+}
+
+// computeRenames decides, for every named object across refSections, whether
+// it collides with an already-merged object of the same name but different
+// content; colliding names are suffixed with a short hash of sourceFile.
+func computeRenames(bundled, spec map[string]interface{}, sourceFile string, report *RefMergeReport) map[string]string {
+	rename := make(map[string]string)
+
+	bundledComponents, _ := bundled["components"].(map[string]interface{})
+	specComponents, _ := spec["components"].(map[string]interface{})
+	if specComponents == nil {
+		return rename
+	}
+
+	suffix := shortHash(sourceFile)
+
+	for _, section := range refSections {
+		specSection, ok := specComponents[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var bundledSection map[string]interface{}
+		if bundledComponents != nil {
+			bundledSection, _ = bundledComponents[section].(map[string]interface{})
+		}
+
+		for name, def := range specSection {
+			existing, exists := bundledSection[name]
+			if !exists || deepEqual(existing, def) {
+				continue
+			}
+
+			newName := fmt.Sprintf("%s_%s", name, suffix)
+			rename[section+"/"+name] = newName
+			report.Renames[fmt.Sprintf("%s:%s/%s", sourceFile, section, name)] = newName
+		}
+	}
+
+	return rename
+}
+
+// rewriteRefs walks spec's paths and components in place, rewriting any
+// $ref value whose section/name pair appears in rename.
+func rewriteRefs(spec map[string]interface{}, rename map[string]string) {
+	if len(rename) == 0 {
+		return
+	}
+	walk(spec, func(m map[string]interface{}) {
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return
+		}
+		section, name, ok := parseComponentRef(ref)
+		if !ok {
+			return
+		}
+		if newName, ok := rename[section+"/"+name]; ok {
+			m["$ref"] = fmt.Sprintf("#/components/%s/%s", section, newName)
+		}
+	})
+}
+
+// mergeComponents copies every (possibly renamed) components.* entry from
+// spec into bundled.
+func mergeComponents(bundled, spec map[string]interface{}, rename map[string]string) {
+	specComponents, ok := spec["components"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if bundled["components"] == nil {
+		bundled["components"] = make(map[string]interface{})
+	}
+	bundledComponents := bundled["components"].(map[string]interface{})
+
+	for _, section := range refSections {
+		specSection, ok := specComponents[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if bundledComponents[section] == nil {
+			bundledComponents[section] = make(map[string]interface{})
+		}
+		bundledSection := bundledComponents[section].(map[string]interface{})
+
+		for name, def := range specSection {
+			targetName := name
+			if newName, ok := rename[section+"/"+name]; ok {
+				targetName = newName
+			}
+			if _, exists := bundledSection[targetName]; !exists {
+				bundledSection[targetName] = def
+			}
+		}
+	}
+}
+
+// mergePaths copies spec's paths into bundled, skipping (and warning on) any
+// path that's already present, same as the pre-existing naive merge.
+func mergePaths(bundled, spec map[string]interface{}, sourceFile string) {
+	paths, ok := spec["paths"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	bundledPaths, _ := bundled["paths"].(map[string]interface{})
+	if bundledPaths == nil {
+		bundledPaths = make(map[string]interface{})
+		bundled["paths"] = bundledPaths
+	}
+	for path, pathItem := range paths {
+		if _, exists := bundledPaths[path]; exists {
+			fmt.Fprintf(os.Stderr, "Warning: Path %s already exists, skipping from %s\n", path, sourceFile)
+			continue
+		}
+		bundledPaths[path] = pathItem
+	}
+}
+
+// detectRefCycles walks every $ref reachable from spec's paths, following
+// chains through spec's own components, and records (without breaking) any
+// cycle it finds: a grey (in-progress) ref being revisited.
+func detectRefCycles(spec map[string]interface{}, report *RefMergeReport) {
+	components, _ := spec["components"].(map[string]interface{})
+	states := make(map[string]nodeState)
+
+	var visit func(ref string, chain string)
+	visit = func(ref string, chain string) {
+		switch states[ref] {
+		case stateBlack:
+			return
+		case stateGrey:
+			report.Cycles = append(report.Cycles, chain+" -> "+ref)
+			return
+		}
+
+		states[ref] = stateGrey
+		section, name, ok := parseComponentRef(ref)
+		if ok && components != nil {
+			if sectionMap, ok := components[section].(map[string]interface{}); ok {
+				if def, ok := sectionMap[name]; ok {
+					forEachRef(def, func(nestedRef string) {
+						visit(nestedRef, chain+" -> "+ref)
+					})
+				}
+			}
+		}
+		states[ref] = stateBlack
+	}
+
+	forEachRef(spec["paths"], func(ref string) {
+		visit(ref, "paths")
+	})
+}
+
+// forEachRef calls fn with every $ref value found anywhere under node.
+func forEachRef(node interface{}, fn func(ref string)) {
+	walk(node, func(m map[string]interface{}) {
+		if ref, ok := m["$ref"].(string); ok {
+			fn(ref)
+		}
+	})
+}
+
+// walk recursively visits every map[string]interface{} reachable under
+// node (through nested maps and slices), invoking visit on each.
+func walk(node interface{}, visit func(map[string]interface{})) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		visit(v)
+		for _, child := range v {
+			walk(child, visit)
+		}
+	case []interface{}:
+		for _, child := range v {
+			walk(child, visit)
+		}
+	}
+}
+
+// parseComponentRef splits a "#/components/<section>/<name>" ref into its
+// section and name.
+func parseComponentRef(ref string) (section, name string, ok bool) {
+	const prefix = "#/components/"
+	if len(ref) <= len(prefix) || ref[:len(prefix)] != prefix {
+		return "", "", false
+	}
+	rest := ref[len(prefix):]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == '/' {
+			return rest[:i], rest[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// shortHash returns the first 8 hex characters of sha256(s), used to build
+// a short, deterministic, collision-resistant rename suffix.
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// deepEqual reports whether two decoded YAML values are structurally equal.
+// Used to distinguish a harmless duplicate definition (same name, same
+// content) from an actual name collision that needs renaming.
+func deepEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			bvv, ok := bv[k]
+			if !ok || !deepEqual(v, bvv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !deepEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import "testing"
+
+func TestMergeSpecIntoBundle_RenamesCollidingComponentAndRewritesRefs(t *testing.T) {
+	bundled := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/method.one": map[string]interface{}{
+				"post": map[string]interface{}{
+					"requestBody": map[string]interface{}{
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"$ref": "#/components/schemas/JsonRpcError"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"JsonRpcError": map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"code": map[string]interface{}{"enum": []interface{}{-1, -2}}},
+				},
+			},
+		},
+	}
+
+	spec := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/method.two": map[string]interface{}{
+				"post": map[string]interface{}{
+					"requestBody": map[string]interface{}{
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"$ref": "#/components/schemas/JsonRpcError"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"JsonRpcError": map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"code": map[string]interface{}{"enum": []interface{}{-10, -20}}},
+				},
+			},
+		},
+	}
+
+	report := newRefMergeReport()
+	mergeSpecIntoBundle(bundled, spec, "two.yaml", report)
+
+	if len(report.Renames) != 1 {
+		t.Fatalf("expected exactly 1 rename to be reported, got %d: %v", len(report.Renames), report.Renames)
+	}
+
+	var renamed string
+	for _, v := range report.Renames {
+		renamed = v
+	}
+	if renamed == "JsonRpcError" {
+		t.Fatal("expected the colliding schema to be renamed to something other than the original name")
+	}
+
+	methodTwoRef := schemaRefFor(bundled, "/method.two")
+	if methodTwoRef != "#/components/schemas/"+renamed {
+		t.Errorf("expected method.two's own $ref to be rewritten to %q, got %q", renamed, methodTwoRef)
+	}
+
+	methodOneRef := schemaRefFor(bundled, "/method.one")
+	if methodOneRef != "#/components/schemas/JsonRpcError" {
+		t.Errorf("expected method.one's $ref to be untouched, got %q", methodOneRef)
+	}
+
+	schemas := bundled["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if _, ok := schemas["JsonRpcError"]; !ok {
+		t.Error("expected the original JsonRpcError to still be present")
+	}
+	if _, ok := schemas[renamed]; !ok {
+		t.Errorf("expected the renamed schema %q to be present in the merged components", renamed)
+	}
+}
+
+func TestMergeSpecIntoBundle_IdenticalComponentIsNotRenamed(t *testing.T) {
+	shared := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}},
+	}
+
+	bundled := map[string]interface{}{
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{"Shared": deepCopyValue(shared)},
+		},
+	}
+	spec := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/method.two": map[string]interface{}{
+				"post": map[string]interface{}{
+					"requestBody": map[string]interface{}{
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"$ref": "#/components/schemas/Shared"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{"Shared": deepCopyValue(shared)},
+		},
+	}
+
+	report := newRefMergeReport()
+	mergeSpecIntoBundle(bundled, spec, "two.yaml", report)
+
+	if len(report.Renames) != 0 {
+		t.Errorf("expected no renames for an identical component, got %v", report.Renames)
+	}
+
+	schemas := bundled["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if len(schemas) != 1 {
+		t.Errorf("expected the identical component to collapse onto 1 entry, got %d: %v", len(schemas), schemas)
+	}
+}
+
+func TestDetectRefCycles_ReportsACycle(t *testing.T) {
+	spec := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/method.one": map[string]interface{}{
+				"post": map[string]interface{}{
+					"requestBody": map[string]interface{}{
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"$ref": "#/components/schemas/A"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"A": map[string]interface{}{"properties": map[string]interface{}{"b": map[string]interface{}{"$ref": "#/components/schemas/B"}}},
+				"B": map[string]interface{}{"properties": map[string]interface{}{"a": map[string]interface{}{"$ref": "#/components/schemas/A"}}},
+			},
+		},
+	}
+
+	report := newRefMergeReport()
+	detectRefCycles(spec, report)
+
+	if len(report.Cycles) == 0 {
+		t.Error("expected a $ref cycle between A and B to be detected")
+	}
+}
+
+func TestDetectRefCycles_NoFalsePositiveOnDiamond(t *testing.T) {
+	// A $ref reached through two different paths (a "diamond", not a cycle)
+	// shouldn't be flagged: C is visited from both A and B but never while
+	// still being resolved.
+	spec := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/method.one": map[string]interface{}{
+				"post": map[string]interface{}{
+					"requestBody": map[string]interface{}{
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"$ref": "#/components/schemas/A"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"A": map[string]interface{}{"properties": map[string]interface{}{
+					"b": map[string]interface{}{"$ref": "#/components/schemas/B"},
+					"c": map[string]interface{}{"$ref": "#/components/schemas/C"},
+				}},
+				"B": map[string]interface{}{"properties": map[string]interface{}{"c": map[string]interface{}{"$ref": "#/components/schemas/C"}}},
+				"C": map[string]interface{}{"type": "object"},
+			},
+		},
+	}
+
+	report := newRefMergeReport()
+	detectRefCycles(spec, report)
+
+	if len(report.Cycles) != 0 {
+		t.Errorf("expected no cycle for a diamond-shaped (non-cyclic) ref graph, got %v", report.Cycles)
+	}
+}
+
+func schemaRefFor(doc map[string]interface{}, path string) string {
+	pathItem := doc["paths"].(map[string]interface{})[path].(map[string]interface{})
+	post := pathItem["post"].(map[string]interface{})
+	body := post["requestBody"].(map[string]interface{})
+	schema := body["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	ref, _ := schema["$ref"].(string)
+	return ref
+}
+
+func deepCopyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = deepCopyValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = deepCopyValue(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
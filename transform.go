@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Transform mutates a decoded OpenAPI document in place. Transforms run
+// over a bundle's marshaled YAML (in createBundledSpec just before the file
+// is written, or after the versioned compile.Compiler has written it),
+// decoded into a fresh yaml.Node so map-based transforms
+// (like RemoveExtensions) can still preserve comments and key ordering.
+type Transform interface {
+	Apply(doc *yaml.Node) error
+}
+
+// applyTransforms decodes data as a YAML document, runs every transform
+// over it in order, and re-encodes the result.
+func applyTransforms(data []byte, transforms []Transform) ([]byte, error) {
+	if len(transforms) == 0 {
+		return data, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode document for transforms: %w", err)
+	}
+
+	for _, t := range transforms {
+		if err := t.Apply(&doc); err != nil {
+			return nil, fmt.Errorf("apply transform: %w", err)
+		}
+	}
+
+	return yaml.Marshal(&doc)
+}
+
+// FlattenTransform inlines every $ref that points into this document's own
+// components section, leaving no components left to dereference. Mirrors
+// how `odo init` returns a flattened Devfile with its parents inlined.
+// Shared schemas are deep-copied per use site so mutating one inlined copy
+// (e.g. a later RemoveExtensions pass) can't affect a sibling, and a ref
+// chain that cycles back on itself is left as a $ref rather than flattened.
+type FlattenTransform struct{}
+
+// Apply implements Transform.
+func (FlattenTransform) Apply(doc *yaml.Node) error {
+	root := documentRoot(doc)
+	if root == nil {
+		return nil
+	}
+
+	components := mapGet(root, "components")
+	if components == nil {
+		return nil
+	}
+
+	paths := mapGet(root, "paths")
+	if paths != nil {
+		resolveRefs(paths, components, map[string]bool{})
+	}
+
+	if !containsRef(paths) {
+		mapDelete(root, "components")
+	}
+
+	return nil
+}
+
+// resolveRefs replaces every {$ref: "#/components/..."} node reachable from
+// node with a deep copy of its referenced target, recursing into the copy
+// so nested refs are inlined too. inProgress tracks the chain of refs
+// currently being resolved so a cycle is detected and left as a $ref.
+func resolveRefs(node *yaml.Node, components *yaml.Node, inProgress map[string]bool) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	if ref, ok := refTarget(node); ok {
+		target, found := lookupComponentRef(components, ref)
+		if !found || inProgress[ref] {
+			return node
+		}
+
+		inProgress[ref] = true
+		resolved := resolveRefs(deepCopyNode(target), components, inProgress)
+		delete(inProgress, ref)
+		return resolved
+	}
+
+	for i, child := range node.Content {
+		node.Content[i] = resolveRefs(child, components, inProgress)
+	}
+	return node
+}
+
+// refTarget returns a mapping node's $ref value when it's a bare {$ref:
+// "..."} node (the only shape OpenAPI ref nodes take).
+func refTarget(node *yaml.Node) (string, bool) {
+	if node.Kind != yaml.MappingNode || len(node.Content) != 2 {
+		return "", false
+	}
+	if node.Content[0].Value != "$ref" {
+		return "", false
+	}
+	return node.Content[1].Value, true
+}
+
+// lookupComponentRef resolves "#/components/<section>/<name>" against
+// components.
+func lookupComponentRef(components *yaml.Node, ref string) (*yaml.Node, bool) {
+	section, name, ok := parseComponentRef(ref)
+	if !ok {
+		return nil, false
+	}
+	sectionNode := mapGet(components, section)
+	if sectionNode == nil {
+		return nil, false
+	}
+	target := mapGet(sectionNode, name)
+	if target == nil {
+		return nil, false
+	}
+	return target, true
+}
+
+// containsRef reports whether node (or anything reachable from it) is
+// still a $ref node, e.g. one FlattenTransform left intact due to a cycle.
+func containsRef(node *yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	if _, ok := refTarget(node); ok {
+		return true
+	}
+	for _, child := range node.Content {
+		if containsRef(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveExtensionsTransform drops keys matching any of Globs from every
+// mapping node in the document, recursing over map/sequence yaml.Node
+// values so the rest of the document's comments and key ordering survive
+// untouched.
+type RemoveExtensionsTransform struct {
+	// Globs match against a mapping key, e.g. "x-internal-*". Empty uses
+	// DefaultExtensionGlobs.
+	Globs []string
+}
+
+// DefaultExtensionGlobs is the key patterns stripped by a zero-value
+// RemoveExtensionsTransform: Novofon's own internal markers.
+var DefaultExtensionGlobs = []string{"x-internal-*", "x-novofon-source"}
+
+// Apply implements Transform.
+func (t RemoveExtensionsTransform) Apply(doc *yaml.Node) error {
+	globs := t.Globs
+	if len(globs) == 0 {
+		globs = DefaultExtensionGlobs
+	}
+	removeExtensions(doc, globs)
+	return nil
+}
+
+func removeExtensions(node *yaml.Node, globs []string) {
+	if node == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			removeExtensions(child, globs)
+		}
+	case yaml.MappingNode:
+		kept := node.Content[:0]
+		for i := 0; i < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+			if matchesAnyGlob(key.Value, globs) {
+				continue
+			}
+			removeExtensions(val, globs)
+			kept = append(kept, key, val)
+		}
+		node.Content = kept
+	}
+}
+
+func matchesAnyGlob(s string, globs []string) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, s); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// documentRoot returns doc's top-level mapping, unwrapping the
+// yaml.DocumentNode wrapper yaml.Unmarshal produces.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode {
+		if len(doc.Content) == 0 {
+			return nil
+		}
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// mapGet returns the value node for key in mapping m, or nil.
+func mapGet(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mapDelete removes key's entry from mapping m, if present.
+func mapDelete(m *yaml.Node, key string) {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// deepCopyNode clones node and everything reachable from it, so inlining
+// the same shared schema at two use sites never lets a later in-place edit
+// (e.g. RemoveExtensions) at one site bleed into the other.
+func deepCopyNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	clone := *node
+	if node.Content != nil {
+		clone.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			clone.Content[i] = deepCopyNode(child)
+		}
+	}
+	return &clone
+}